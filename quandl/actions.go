@@ -0,0 +1,151 @@
+package quandl
+
+import "math"
+
+// Columns of the WIKI stock datasets.
+const (
+	ColumnOpen       = "Open"
+	ColumnHigh       = "High"
+	ColumnLow        = "Low"
+	ColumnClose      = "Close"
+	ColumnVolume     = "Volume"
+	ColumnExDividend = "Ex-Dividend"
+	ColumnSplitRatio = "Split Ratio"
+	ColumnAdjOpen    = "Adj. Open"
+	ColumnAdjHigh    = "Adj. High"
+	ColumnAdjLow     = "Adj. Low"
+	ColumnAdjClose   = "Adj. Close"
+	ColumnAdjVolume  = "Adj. Volume"
+)
+
+// Dividend is a cash dividend going ex on Date.
+type Dividend struct {
+	Date   string
+	Amount float64 // per share
+}
+
+// Split is a stock split taking effect on Date.
+type Split struct {
+	Date  string
+	Ratio float64 // new shares per old share, e.g. 7 for a 7 for 1 split
+}
+
+// CorporateActions are the dividends and splits in a dataset, oldest first.
+type CorporateActions struct {
+	Dividends []Dividend
+	Splits    []Split
+}
+
+// CorporateActions returns the dividends and splits in the Ex-Dividend and
+// Split Ratio columns of a WIKI dataset. Missing values are ignored.
+func (q *QuandlResponse) CorporateActions() (CorporateActions, error) {
+	var actions CorporateActions
+
+	dates, values, err := q.readColumns(ColumnExDividend, ColumnSplitRatio)
+	if err != nil {
+		return actions, err
+	}
+
+	dividends, splits := values[0], values[1]
+	for i, date := range dates {
+		if d := dividends[i]; d != 0 && !math.IsNaN(d) {
+			actions.Dividends = append(actions.Dividends, Dividend{Date: date, Amount: d})
+		}
+		if r := splits[i]; r != 0 && r != 1 && !math.IsNaN(r) {
+			actions.Splits = append(actions.Splits, Split{Date: date, Ratio: r})
+		}
+	}
+
+	return actions, nil
+}
+
+// AdjustmentFactors computes the factor that adjusts each day's raw prices
+// for the dividends and splits after it, oldest first, so that Close times
+// the factor is the adjusted close. A dividend D going ex the day after a
+// close C scales earlier prices by 1 - D/C and a split of ratio R by 1/R,
+// which is how the WIKI "Adj." columns are calculated. The latest factor is
+// always 1. A dividend after a missing close is ignored.
+func (q *QuandlResponse) AdjustmentFactors() (Series, error) {
+	dates, values, err := q.readColumns(ColumnClose, ColumnExDividend, ColumnSplitRatio)
+	if err != nil {
+		return Series{}, err
+	}
+
+	return Series{Dates: dates, Values: adjustmentFactors(values[0], values[1], values[2])}, nil
+}
+
+// adjustmentFactors is AdjustmentFactors over the closes, dividends and
+// split ratios of the same days, oldest first.
+func adjustmentFactors(closes []float64, dividends []float64, splits []float64) []float64 {
+	n := len(closes)
+	factors := make([]float64, n)
+	if n == 0 {
+		return factors
+	}
+
+	factors[n-1] = 1
+	for i := n - 2; i >= 0; i-- {
+		factor := 1.0
+		if d, c := dividends[i+1], closes[i]; d != 0 && !math.IsNaN(d) && c != 0 && !math.IsNaN(c) {
+			factor *= 1 - d/c
+		}
+		if r := splits[i+1]; r != 0 && !math.IsNaN(r) {
+			factor /= r
+		}
+
+		factors[i] = factors[i+1] * factor
+	}
+
+	return factors
+}
+
+// AdjustmentDifference is a day on which the adjusted close computed from
+// AdjustmentFactors differs from the dataset's Adj. Close.
+type AdjustmentDifference struct {
+	Date     string
+	Computed float64
+	Quandl   float64
+	Relative float64 // (Computed - Quandl) / Quandl
+}
+
+// CompareAdjustments recomputes the adjusted close from the raw close,
+// dividends and splits and returns the days on which it differs from the
+// Adj. Close column by more than tolerance, relative to Adj. Close, e.g.
+// 0.001 for 0.1%. Days missing either close are skipped.
+func (q *QuandlResponse) CompareAdjustments(tolerance float64) ([]AdjustmentDifference, error) {
+	dates, values, err := q.readColumns(ColumnClose, ColumnExDividend, ColumnSplitRatio, ColumnAdjClose)
+	if err != nil {
+		return nil, err
+	}
+
+	closes, adjusted := values[0], values[3]
+	factors := adjustmentFactors(closes, values[1], values[2])
+
+	var differences []AdjustmentDifference
+	for i, date := range dates {
+		if math.IsNaN(closes[i]) || math.IsNaN(adjusted[i]) {
+			continue
+		}
+
+		computed := closes[i] * factors[i]
+		quandl := adjusted[i]
+
+		relative := 0.0
+		if quandl != 0 {
+			relative = (computed - quandl) / quandl
+		} else if computed != 0 {
+			relative = math.Inf(1)
+		}
+
+		if math.Abs(relative) > tolerance {
+			differences = append(differences, AdjustmentDifference{
+				Date:     date,
+				Computed: computed,
+				Quandl:   quandl,
+				Relative: relative,
+			})
+		}
+	}
+
+	return differences, nil
+}
@@ -0,0 +1,41 @@
+package quandl
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// wikiDataset is a WIKI dataset, newest first as Quandl returns it, with a
+// 2 for 1 split and a dividend. The Adj. Close on 2014-06-05 is wrong.
+const wikiDataset = `{
+	"code": "AAPL",
+	"column_names": ["Date", "Open", "High", "Low", "Close", "Volume", "Ex-Dividend", "Split Ratio",
+		"Adj. Open", "Adj. High", "Adj. Low", "Adj. Close", "Adj. Volume"],
+	"data": [
+		["2014-06-10", 51, 52, 50, 51, 2000, 0, 1, 51, 52, 50, 51, 2000],
+		["2014-06-09", 49.5, 50.5, 49, 50, 1800, 0.5, 1, 49.5, 50.5, 49, 50, 1800],
+		["2014-06-06", 50, 51, 49.5, 50, 2200, 0, 2, 49.5, 50.49, 49.005, 49.5, 2200],
+		["2014-06-05", 99, 100, 96, 98, 1000, 0, 1, 49.005, 49.5, 47.52, 49.0, 2000]
+	]
+}`
+
+func ExampleQuandlResponse_CorporateActions() {
+	var q QuandlResponse
+	json.Unmarshal([]byte(wikiDataset), &q)
+
+	actions, err := q.CorporateActions()
+	fmt.Printf("%+v %v\n", actions, err)
+
+	factors, _ := q.AdjustmentFactors()
+	fmt.Printf("%.4f\n", factors.Values)
+
+	differences, _ := q.CompareAdjustments(0.001)
+	for _, d := range differences {
+		fmt.Printf("%s computed %.4f quandl %.4f (%+.2f%%)\n", d.Date, d.Computed, d.Quandl, 100*d.Relative)
+	}
+
+	// Output:
+	// {Dividends:[{Date:2014-06-09 Amount:0.5}] Splits:[{Date:2014-06-06 Ratio:2}]} <nil>
+	// [0.4950 0.9900 1.0000 1.0000]
+	// 2014-06-05 computed 48.5100 quandl 49.0000 (-1.00%)
+}
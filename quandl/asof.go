@@ -0,0 +1,68 @@
+package quandl
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// dateFormat is the layout of the dates in Quandl data.
+const dateFormat = "2006-01-02"
+
+// AsOfJoin aligns a low frequency series, given by dates and values, with
+// the target dates. For each target date it takes the latest value observed
+// at least lagDays days earlier, so that with a lag matching the reporting
+// delay no value is used before it was published. Target dates with no such
+// value get NaN. Dates are in the 2006-01-02 format used by Quandl and may be
+// in any order; the result is in the order of target.
+func AsOfJoin(target []string, dates []string, values []float64, lagDays int) ([]float64, error) {
+	if len(dates) != len(values) {
+		return nil, fmt.Errorf("quandl: %d dates but %d values", len(dates), len(values))
+	}
+
+	type observation struct {
+		available time.Time
+		value     float64
+	}
+
+	observations := make([]observation, len(dates))
+	for i, date := range dates {
+		t, err := time.Parse(dateFormat, date)
+		if err != nil {
+			return nil, fmt.Errorf("quandl: cannot read %q as a date", date)
+		}
+		observations[i] = observation{t.AddDate(0, 0, lagDays), values[i]}
+	}
+	sort.SliceStable(observations, func(i, j int) bool {
+		return observations[i].available.Before(observations[j].available)
+	})
+
+	joined := make([]float64, len(target))
+	for i, date := range target {
+		t, err := time.Parse(dateFormat, date)
+		if err != nil {
+			return nil, fmt.Errorf("quandl: cannot read %q as a date", date)
+		}
+
+		// The first observation not yet available, so the one before it is
+		// the latest that is
+		j := sort.Search(len(observations), func(j int) bool {
+			return observations[j].available.After(t)
+		})
+		if j == 0 {
+			joined[i] = math.NaN()
+		} else {
+			joined[i] = observations[j-1].value
+		}
+	}
+
+	return joined, nil
+}
+
+// AsOf aligns ratio for ticker with the target dates, see AsOfJoin.
+func (p *Panel) AsOf(ticker string, ratio Ratio, target []string, lagDays int) ([]float64, error) {
+	dates, values := p.Series(ticker, ratio)
+
+	return AsOfJoin(target, dates, values, lagDays)
+}
@@ -0,0 +1,33 @@
+package quandl
+
+import "fmt"
+
+func ExampleAsOfJoin() {
+	// Daily closes and annual earnings per share, newest first as Quandl
+	// returns them
+	days := []string{"2016-03-31", "2016-03-01", "2016-01-04", "2015-12-31"}
+	closes := []float64{108.99, 100.53, 105.35, 105.26}
+	years := []string{"2015-12-31", "2014-12-31"}
+	eps := []float64{9.22, 6.45}
+
+	// Annual figures are published about 60 days after the year ends
+	joined, err := AsOfJoin(days, years, eps, 60)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for i, day := range days {
+		fmt.Printf("%s EPS %.2f PE %.1f\n", day, joined[i], closes[i]/joined[i])
+	}
+
+	joined, _ = AsOfJoin([]string{"2014-06-30"}, years, eps, 0)
+	fmt.Println(joined)
+
+	// Output:
+	// 2016-03-31 EPS 9.22 PE 11.8
+	// 2016-03-01 EPS 9.22 PE 10.9
+	// 2016-01-04 EPS 6.45 PE 16.3
+	// 2015-12-31 EPS 6.45 PE 16.3
+	// [NaN]
+}
@@ -0,0 +1,105 @@
+package quandl
+
+import "fmt"
+
+// Bar is one day of prices and volume.
+type Bar struct {
+	Date   string
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume float64
+}
+
+// Bars is a sequence of bars, oldest first.
+type Bars []Bar
+
+var (
+	rawBarColumns      = []string{ColumnOpen, ColumnHigh, ColumnLow, ColumnClose, ColumnVolume}
+	adjustedBarColumns = []string{ColumnAdjOpen, ColumnAdjHigh, ColumnAdjLow, ColumnAdjClose, ColumnAdjVolume}
+)
+
+// OHLCV reads the open, high, low, close and volume of every row in one
+// pass, oldest first. With adjusted it reads the "Adj." columns of a WIKI
+// dataset instead of the raw ones. Missing values are returned as NaN.
+func (q *QuandlResponse) OHLCV(adjusted bool) (Bars, error) {
+	columns := rawBarColumns
+	if adjusted {
+		columns = adjustedBarColumns
+	}
+
+	dates, values, err := q.readColumns(columns...)
+	if err != nil {
+		return nil, err
+	}
+	if dates == nil {
+		return nil, nil
+	}
+
+	bars := make(Bars, len(dates))
+	for i, date := range dates {
+		bars[i] = Bar{
+			Date:   date,
+			Open:   values[0][i],
+			High:   values[1][i],
+			Low:    values[2][i],
+			Close:  values[3][i],
+			Volume: values[4][i],
+		}
+	}
+
+	return bars, nil
+}
+
+// BarProblem is something impossible about a bar.
+type BarProblem struct {
+	Date    string
+	Problem string
+}
+
+func (p BarProblem) String() string {
+	return p.Date + ": " + p.Problem
+}
+
+// Validate returns a problem for every bar whose high is below its low,
+// whose open or close is otherwise outside the range from low to high, or
+// whose volume is negative. A bar can have several problems. Missing values,
+// which are NaN, are not checked.
+func (b Bars) Validate() []BarProblem {
+	var problems []BarProblem
+
+	add := func(bar Bar, format string, args ...interface{}) {
+		problems = append(problems, BarProblem{Date: bar.Date, Problem: fmt.Sprintf(format, args...)})
+	}
+
+	// Every comparison with NaN is false, so missing values are skipped
+	for _, bar := range b {
+		if bar.High < bar.Low {
+			add(bar, "high %v is below low %v", bar.High, bar.Low)
+		} else {
+			if bar.Open < bar.Low || bar.Open > bar.High {
+				add(bar, "open %v is outside the range %v to %v", bar.Open, bar.Low, bar.High)
+			}
+			if bar.Close < bar.Low || bar.Close > bar.High {
+				add(bar, "close %v is outside the range %v to %v", bar.Close, bar.Low, bar.High)
+			}
+		}
+		if bar.Volume < 0 {
+			add(bar, "volume %v is negative", bar.Volume)
+		}
+	}
+
+	return problems
+}
+
+// Closes returns the closing prices as a Series. Missing closes are NaN.
+func (b Bars) Closes() Series {
+	s := Series{Dates: make([]string, len(b)), Values: make([]float64, len(b))}
+	for i, bar := range b {
+		s.Dates[i] = bar.Date
+		s.Values[i] = bar.Close
+	}
+
+	return s
+}
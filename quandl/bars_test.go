@@ -0,0 +1,47 @@
+package quandl
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+func ExampleQuandlResponse_OHLCV() {
+	var q QuandlResponse
+	json.Unmarshal([]byte(wikiDataset), &q)
+
+	raw, err := q.OHLCV(false)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	adjusted, _ := q.OHLCV(true)
+
+	fmt.Printf("%+v\n", raw[0])
+	fmt.Printf("%+v\n", adjusted[0])
+	fmt.Println(raw.Validate())
+
+	// A missing open is NaN and not reported as a problem
+	gap := QuandlResponse{
+		Columns: []string{"Date", ColumnOpen, ColumnHigh, ColumnLow, ColumnClose, ColumnVolume},
+		Data:    []interface{}{[]interface{}{"2014-06-13", nil, 52.0, 49.0, 51.0, 1000.0}},
+	}
+	gapBars, _ := gap.OHLCV(false)
+	fmt.Printf("%+v %v\n", gapBars[0], gapBars.Validate())
+
+	bad := Bars{
+		{Date: "2014-06-11", Open: 51, High: 50, Low: 52, Close: 51, Volume: 100},
+		{Date: "2014-06-12", Open: 51, High: 52, Low: 50, Close: 53, Volume: -1},
+	}
+	for _, p := range bad.Validate() {
+		fmt.Println(p)
+	}
+
+	// Output:
+	// {Date:2014-06-05 Open:99 High:100 Low:96 Close:98 Volume:1000}
+	// {Date:2014-06-05 Open:49.005 High:49.5 Low:47.52 Close:49 Volume:2000}
+	// []
+	// {Date:2014-06-13 Open:NaN High:52 Low:49 Close:51 Volume:1000} []
+	// 2014-06-11: high 50 is below low 52
+	// 2014-06-12: close 53 is outside the range 50 to 52
+	// 2014-06-12: volume -1 is negative
+}
@@ -0,0 +1,111 @@
+package quandl
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// diskCache stores response bodies in a directory, one file per URL.
+type diskCache struct {
+	dir string
+	ttl time.Duration
+}
+
+// WithCache caches responses in dir for ttl. A ttl of zero keeps entries
+// until they are deleted. Cache keys never include the auth token.
+func WithCache(dir string, ttl time.Duration) Option {
+	return func(c *Client) error {
+		if dir == "" {
+			return errors.New("quandl: empty cache directory")
+		}
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return err
+		}
+		c.cache = &diskCache{dir: dir, ttl: ttl}
+		return nil
+	}
+}
+
+func (d *diskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:]))
+}
+
+func (d *diskCache) get(key string) ([]byte, bool) {
+	path := d.path(key)
+
+	info, err := os.Stat(path)
+	if err != nil || (d.ttl > 0 && time.Since(info.ModTime()) > d.ttl) {
+		return nil, false
+	}
+
+	body, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	return body, true
+}
+
+// put writes to a temporary file first so readers never see a partial entry.
+func (d *diskCache) put(key string, body []byte) error {
+	f, err := ioutil.TempFile(d.dir, "tmp-")
+	if err != nil {
+		return err
+	}
+
+	if _, err = f.Write(body); err == nil {
+		err = f.Close()
+	} else {
+		f.Close()
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), d.path(key))
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+
+	return err
+}
+
+// rateLimiter spaces requests evenly so that no more than a fixed number are
+// started each second.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// WithRateLimit limits the client to perSecond requests per second. Cached
+// responses do not count towards the limit.
+func WithRateLimit(perSecond float64) Option {
+	return func(c *Client) error {
+		if perSecond <= 0 {
+			return errors.New("quandl: rate limit must be positive")
+		}
+		c.limiter = &rateLimiter{interval: time.Duration(float64(time.Second) / perSecond)}
+		return nil
+	}
+}
+
+// wait blocks until the next request may start.
+func (l *rateLimiter) wait() {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	time.Sleep(delay)
+}
@@ -0,0 +1,480 @@
+package quandl
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AssetClass is the kind of security a Quandl code refers to.
+type AssetClass int
+
+const (
+	UnknownAssetClass AssetClass = iota
+	Stock
+	ETF
+	StockIndex
+	Commodity
+	Currency
+	MutualFund
+	Crypto
+)
+
+var assetClassNames = []string{"Unknown", "Stock", "ETF", "Index", "Commodity", "Currency", "Mutual Fund", "Crypto"}
+
+func (a AssetClass) String() string {
+	if a < 0 || int(a) >= len(assetClassNames) {
+		return fmt.Sprintf("AssetClass(%d)", int(a))
+	}
+
+	return assetClassNames[a]
+}
+
+// parseAssetClass is the inverse of AssetClass.String.
+func parseAssetClass(s string) AssetClass {
+	for i, name := range assetClassNames {
+		if strings.EqualFold(s, name) {
+			return AssetClass(i)
+		}
+	}
+
+	return UnknownAssetClass
+}
+
+// Security is one entry in the Catalog.
+type Security struct {
+	Code       string // Quandl code, e.g. WIKI/AAPL
+	Ticker     string
+	Name       string
+	AssetClass AssetClass
+	Exchange   string
+	RatiosCode string // Damodaran ratios code, e.g. DMDRN/AAPL_ALLFINANCIALRATIOS
+	Sector     string
+	Industry   string
+
+	// Active and Delisted come from the "In Market?" column of the stock
+	// code list. Both are false when no list gives a status.
+	Active   bool
+	Delisted bool
+
+	// Sources records the list each field came from, keyed by field name,
+	// e.g. Sources[FieldName] is "WIKI_tickers.csv".
+	Sources map[string]string
+}
+
+// merge fills the fields of s that are empty with the values from other,
+// along with where they came from. If either says the security is delisted
+// it stays delisted.
+func (s *Security) merge(other Security) {
+	fill := func(field string, dst *string, src string) {
+		if *dst == "" && src != "" {
+			*dst = src
+			s.setSource(field, other.Sources[field])
+		}
+	}
+
+	fill(FieldTicker, &s.Ticker, other.Ticker)
+	fill(FieldName, &s.Name, other.Name)
+	fill(FieldExchange, &s.Exchange, other.Exchange)
+	fill(FieldRatiosCode, &s.RatiosCode, other.RatiosCode)
+	fill(FieldSector, &s.Sector, other.Sector)
+	fill(FieldIndustry, &s.Industry, other.Industry)
+
+	if s.AssetClass == UnknownAssetClass {
+		s.AssetClass = other.AssetClass
+	}
+	s.Delisted = s.Delisted || other.Delisted
+	s.Active = (s.Active || other.Active) && !s.Delisted
+}
+
+// attribute records source as the origin of every field s has a value for.
+func (s *Security) attribute(source string) {
+	for field, value := range s.fieldValues() {
+		if value != "" {
+			s.setSource(field, source)
+		}
+	}
+}
+
+func (s *Security) fieldValues() map[string]string {
+	return map[string]string{
+		FieldCode:       s.Code,
+		FieldTicker:     s.Ticker,
+		FieldName:       s.Name,
+		FieldExchange:   s.Exchange,
+		FieldRatiosCode: s.RatiosCode,
+		FieldSector:     s.Sector,
+		FieldIndustry:   s.Industry,
+	}
+}
+
+func (s *Security) setSource(field string, source string) {
+	if source == "" {
+		return
+	}
+	if s.Sources == nil {
+		s.Sources = make(map[string]string)
+	}
+	s.Sources[field] = source
+}
+
+// clone returns a copy of s that does not share its Sources map.
+func (s Security) clone() Security {
+	if s.Sources != nil {
+		sources := make(map[string]string, len(s.Sources))
+		for k, v := range s.Sources {
+			sources[k] = v
+		}
+		s.Sources = sources
+	}
+
+	return s
+}
+
+// Catalog is a set of securities, one per Quandl code, that can be looked up
+// by code, ticker or asset class.
+type Catalog struct {
+	securities []Security
+	byCode     map[string]int
+	byTicker   map[string][]int
+}
+
+// NewCatalog creates a catalog from securities. Securities with the same
+// code are merged, with earlier ones taking precedence.
+func NewCatalog(securities []Security) *Catalog {
+	c := &Catalog{
+		byCode:   make(map[string]int),
+		byTicker: make(map[string][]int),
+	}
+
+	for _, s := range securities {
+		c.Add(s)
+	}
+
+	return c
+}
+
+// Add adds s to the catalog, merging it into any security with the same code.
+func (c *Catalog) Add(s Security) {
+	if s.Code == "" {
+		return
+	}
+
+	if i, ok := c.byCode[s.Code]; ok {
+		hadTicker := c.securities[i].Ticker != ""
+		c.securities[i].merge(s)
+		if !hadTicker && c.securities[i].Ticker != "" {
+			c.indexTicker(i)
+		}
+		return
+	}
+
+	c.securities = append(c.securities, s.clone())
+	c.byCode[s.Code] = len(c.securities) - 1
+	c.indexTicker(len(c.securities) - 1)
+}
+
+func (c *Catalog) indexTicker(i int) {
+	if ticker := strings.ToUpper(c.securities[i].Ticker); ticker != "" {
+		c.byTicker[ticker] = append(c.byTicker[ticker], i)
+	}
+}
+
+// Len returns the number of securities in the catalog.
+func (c *Catalog) Len() int {
+	return len(c.securities)
+}
+
+// Securities returns every security in the catalog, in the order added.
+func (c *Catalog) Securities() []Security {
+	securities := make([]Security, len(c.securities))
+	for i, s := range c.securities {
+		securities[i] = s.clone()
+	}
+
+	return securities
+}
+
+// ByCode returns the security with the given Quandl code.
+func (c *Catalog) ByCode(code string) (Security, bool) {
+	i, ok := c.byCode[code]
+	if !ok {
+		return Security{}, false
+	}
+
+	return c.securities[i].clone(), true
+}
+
+// ByTicker returns every security with the given ticker, ignoring case. The
+// same ticker can have several codes, e.g. WIKI/A and GOOG/NYSE_A.
+func (c *Catalog) ByTicker(ticker string) []Security {
+	return c.collect(c.byTicker[strings.ToUpper(ticker)])
+}
+
+// ByAssetClass returns every security of the given asset class.
+func (c *Catalog) ByAssetClass(assetClass AssetClass) []Security {
+	var indices []int
+	for i, s := range c.securities {
+		if s.AssetClass == assetClass {
+			indices = append(indices, i)
+		}
+	}
+
+	return c.collect(indices)
+}
+
+func (c *Catalog) collect(indices []int) []Security {
+	securities := make([]Security, len(indices))
+	for i, j := range indices {
+		securities[i] = c.securities[j].clone()
+	}
+
+	return securities
+}
+
+// catalogSource is one of the lists a catalog is assembled from.
+type catalogSource struct {
+	url     func(Endpoints) string
+	mapping Mapping
+	parse   func([]Row) []Security
+}
+
+// catalogSources are read in order, so earlier lists win when they disagree.
+var catalogSources = []catalogSource{
+	{func(e Endpoints) string { return e.StockWikiList }, wikiListMapping, parseWikiList},
+	{func(e Endpoints) string { return e.StockList }, stockListMapping, parseStockCodeList},
+	{func(e Endpoints) string { return e.ETFList }, codeListMapping, parseETFList},
+	{func(e Endpoints) string { return e.StockIndexList }, codeListMapping, parseStockIndexList},
+	{func(e Endpoints) string { return e.CommoditiesList }, commoditiesListMapping, parseCommoditiesList},
+	{func(e Endpoints) string { return e.SP500Constituents }, constituentListMapping, parseSP500List},
+	{func(e Endpoints) string { return e.StockInfoList }, stockInfoListMapping, parseStockInfoList},
+	{func(e Endpoints) string { return e.MutualFundList }, codeListMapping, parseMutualFundList},
+	{func(e Endpoints) string { return e.CurrencyList }, currencyListMapping, parseCurrencyList},
+}
+
+// GetCatalog assembles a Catalog from all the Quandl ticker lists
+func GetCatalog() (*Catalog, error) {
+	return defaultClient().GetCatalog()
+}
+
+// GetCatalog assembles a Catalog from all the Quandl ticker lists
+func (c *Client) GetCatalog() (*Catalog, error) {
+	return c.assembleCatalog(c.LoadList)
+}
+
+// assembleCatalog builds a catalog from the lists returned by load, noting
+// which list each field came from.
+func (c *Client) assembleCatalog(load func(string, Mapping) ([]Row, error)) (*Catalog, error) {
+	catalog := NewCatalog(nil)
+
+	for _, source := range catalogSources {
+		url := source.url(c.endpoints)
+
+		list, err := load(url, source.mapping)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, s := range source.parse(list) {
+			s.attribute(listFileName(url))
+			catalog.Add(s)
+		}
+	}
+
+	for _, s := range bitcoinSecurities() {
+		s.attribute("GetBitcoinList")
+		catalog.Add(s)
+	}
+
+	return catalog, nil
+}
+
+// GetAllSecurities gets every security in the Quandl ticker lists, one per
+// Quandl code, with the ticker and name merged from whichever lists have them
+func GetAllSecurities() ([]Security, error) {
+	return defaultClient().GetAllSecurities()
+}
+
+// GetAllSecurities gets every security in the Quandl ticker lists, one per
+// Quandl code, with the ticker and name merged from whichever lists have them
+func (c *Client) GetAllSecurities() ([]Security, error) {
+	catalog, err := c.GetCatalog()
+	if err != nil {
+		return nil, err
+	}
+
+	return catalog.Securities(), nil
+}
+
+// exchangeFromCode returns the exchange in a Google Finance code, e.g. NYSE
+// for GOOG/NYSE_A.
+func exchangeFromCode(code string) string {
+	if !strings.HasPrefix(code, "GOOG/") {
+		return ""
+	}
+
+	code = strings.TrimPrefix(code, "GOOG/")
+	if i := strings.Index(code, "_"); i > 0 {
+		return code[:i]
+	}
+
+	return ""
+}
+
+// tickerFromCode returns the ticker in a WIKI or Google Finance code.
+func tickerFromCode(code string) string {
+	if exchange := exchangeFromCode(code); exchange != "" {
+		return strings.TrimPrefix(code, "GOOG/"+exchange+"_")
+	}
+	if i := strings.Index(code, "/"); i >= 0 {
+		return code[i+1:]
+	}
+
+	return code
+}
+
+func parseWikiList(list []Row) []Security {
+	var securities []Security
+	for _, r := range list {
+		code := r[FieldCode]
+		securities = append(securities, Security{
+			Code:       code,
+			Ticker:     tickerFromCode(code),
+			Name:       r[FieldName],
+			AssetClass: Stock,
+		})
+	}
+
+	return securities
+}
+
+func parseStockCodeList(list []Row) []Security {
+	var securities []Security
+	for _, r := range parseSecurityMaster(list) {
+		securities = append(securities, r.Security())
+	}
+
+	return securities
+}
+
+func parseETFList(list []Row) []Security {
+	var securities []Security
+	for _, r := range list {
+		code := r[FieldCode]
+		securities = append(securities, Security{
+			Code:       code,
+			Ticker:     r[FieldTicker],
+			Name:       r[FieldName],
+			AssetClass: ETF,
+			Exchange:   exchangeFromCode(code),
+		})
+	}
+
+	return securities
+}
+
+func parseStockIndexList(list []Row) []Security {
+	var securities []Security
+	for _, r := range list {
+		securities = append(securities, Security{
+			Code:       r[FieldCode],
+			Ticker:     r[FieldTicker],
+			Name:       r[FieldName],
+			AssetClass: StockIndex,
+		})
+	}
+
+	return securities
+}
+
+func parseCommoditiesList(list []Row) []Security {
+	var securities []Security
+	for _, r := range list {
+		securities = append(securities, Security{
+			Code:       r[FieldCode],
+			Name:       r[FieldName],
+			AssetClass: Commodity,
+		})
+	}
+
+	return securities
+}
+
+func parseSP500List(list []Row) []Security {
+	var securities []Security
+	for _, r := range list {
+		ticker := r[FieldTicker]
+		securities = append(securities, Security{
+			Code:       "WIKI/" + ticker,
+			Ticker:     ticker,
+			Name:       r[FieldName],
+			AssetClass: Stock,
+			Sector:     r[FieldSector],
+		})
+	}
+
+	return securities
+}
+
+func bitcoinSecurities() []Security {
+	identifier, description := GetBitcoinList()
+
+	securities := make([]Security, len(identifier))
+	for i := range identifier {
+		securities[i] = Security{
+			Code:       identifier[i],
+			Ticker:     "BTCUSD",
+			Name:       description[i],
+			AssetClass: Crypto,
+			Exchange:   "BITSTAMP",
+		}
+	}
+
+	return securities
+}
+
+func parseStockInfoList(list []Row) []Security {
+	var securities []Security
+	for _, r := range list {
+		ticker := r[FieldTicker]
+		securities = append(securities, Security{
+			Code:       "WIKI/" + ticker,
+			Ticker:     ticker,
+			Name:       r[FieldName],
+			AssetClass: Stock,
+			Exchange:   r[FieldExchange],
+			Sector:     r[FieldSector],
+			Industry:   r[FieldIndustry],
+		})
+	}
+
+	return securities
+}
+
+func parseMutualFundList(list []Row) []Security {
+	var securities []Security
+	for _, r := range list {
+		securities = append(securities, Security{
+			Code:       r[FieldCode],
+			Ticker:     r[FieldTicker],
+			Name:       r[FieldName],
+			AssetClass: MutualFund,
+		})
+	}
+
+	return securities
+}
+
+func parseCurrencyList(list []Row) []Security {
+	var securities []Security
+	for _, r := range list {
+		code := r[FieldCode]
+		securities = append(securities, Security{
+			Code:       prependSources([]string{r[FieldSource]}, []string{code})[0],
+			Ticker:     tickerFromCode(code),
+			Name:       r[FieldName],
+			AssetClass: Currency,
+		})
+	}
+
+	return securities
+}
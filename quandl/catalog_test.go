@@ -0,0 +1,147 @@
+package quandl
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+)
+
+// newMirror serves each file by its base name, standing in for the S3 lists.
+func newMirror(files map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := files[path.Base(r.URL.Path)]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, content)
+	}))
+}
+
+var mirrorFiles = map[string]string{
+	"WIKI_tickers.csv": "quandl code,name\nWIKI/AAPL,Apple Inc.\nWIKI/A,\"Agilent Technologies, Inc.\"\n",
+	"quandl-stock-code-list.csv": "Ticker,Stock Name,Price Code,Ratios Code,In Market?\n" +
+		"A,Agilent Technologies,GOOG/NYSE_A,DMDRN/A_ALLFINANCIALRATIOS,Active\n" +
+		"AAPL,Apple Inc,GOOG/NASDAQ_AAPL,DMDRN/AAPL_ALLFINANCIALRATIOS,Active\n" +
+		"LEH,Lehman Brothers,GOOG/NYSE_LEH,DMDRN/LEH_ALLFINANCIALRATIOS,Delisted\n",
+	"ETFs.csv":        "Ticker,Code,Name\r\nSPY,GOOG/NYSEARCA_SPY,SPDR S&P 500\r\n",
+	"Indicies.csv":    "Ticker,Code,Name\nGSPC,YAHOO/INDEX_GSPC,S&P 500 Index\n",
+	"commodities.csv": "Name,Code\nGold,WGC/GOLD_DAILY_USD\n",
+	"SP500.csv":       "ticker,name,free code,sector\rAAPL,Apple Inc.,WIKI/AAPL,Information Technology\r",
+	"stockinfo.csv":   "Ticker,Name,Exchange,Sector,Industry\nAAPL,Apple Inc.,NASDAQ,Technology,Personal Computers\n",
+	"funds.csv":       "Ticker,Code,Name\nVFINX,GOOG/MUTF_VFINX,Vanguard 500 Index Fund\n",
+	"currencies.csv":  "Code,Name,Source\nUSDEUR,US Dollar vs Euro,CURRFX\nBOE/XUDLBK73,Chinese Yuan vs US Dollar,BOE\n",
+}
+
+func ExampleCatalog() {
+	mirror := newMirror(mirrorFiles)
+	defer mirror.Close()
+
+	c, err := NewClient(WithoutConfig(), WithMirror(mirror.URL))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	catalog, err := c.GetCatalog()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(catalog.Len())
+	for _, s := range catalog.ByTicker("aapl") {
+		fmt.Printf("%s %q %s %q %q %q\n", s.Code, s.Name, s.AssetClass, s.Exchange, s.RatiosCode, s.Sector)
+	}
+	lehman, _ := catalog.ByCode("GOOG/NYSE_LEH")
+	fmt.Println(lehman.Ticker, lehman.Active)
+	fmt.Println(catalog.ByAssetClass(ETF)[0].Name)
+
+	// Output:
+	// 12
+	// WIKI/AAPL "Apple Inc." Stock "NASDAQ" "" "Information Technology"
+	// GOOG/NASDAQ_AAPL "Apple Inc" Stock "NASDAQ" "DMDRN/AAPL_ALLFINANCIALRATIOS" ""
+	// LEH false
+	// SPDR S&P 500
+}
+
+func ExampleCatalog_ByCode() {
+	// WIKI/LEH is in the WIKI list, which has no status, and in the stock
+	// code list as delisted
+	files := make(map[string]string)
+	for name, content := range mirrorFiles {
+		files[name] = content
+	}
+	files["WIKI_tickers.csv"] += "WIKI/LEH,Lehman Brothers Holdings\n"
+	files["quandl-stock-code-list.csv"] += "LEH,Lehman Brothers,WIKI/LEH,DMDRN/LEH_ALLFINANCIALRATIOS,Delisted\n"
+
+	mirror := newMirror(files)
+	defer mirror.Close()
+
+	c, err := NewClient(WithoutConfig(), WithMirror(mirror.URL))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	catalog, err := c.GetCatalog()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for _, code := range []string{"WIKI/LEH", "GOOG/NASDAQ_AAPL", "WIKI/AAPL"} {
+		s, _ := catalog.ByCode(code)
+		fmt.Println(s.Code, s.Active, s.Delisted)
+	}
+
+	// Output:
+	// WIKI/LEH false true
+	// GOOG/NASDAQ_AAPL true false
+	// WIKI/AAPL false false
+}
+
+func ExampleGetCurrencyList() {
+	mirror := newMirror(mirrorFiles)
+	defer mirror.Close()
+
+	c, err := NewClient(WithoutConfig(), WithMirror(mirror.URL))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	identifier, description := c.GetCurrencyList()
+
+	fmt.Printf("%q : %q\n", identifier, description)
+
+	// Output:
+	// ["CURRFX/USDEUR" "BOE/XUDLBK73"] : ["US Dollar vs Euro" "Chinese Yuan vs US Dollar"]
+}
+
+func ExampleGetAllSecurities() {
+	mirror := newMirror(mirrorFiles)
+	defer mirror.Close()
+
+	c, err := NewClient(WithoutConfig(), WithMirror(mirror.URL))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	securities, _ := c.GetAllSecurities()
+
+	for _, s := range securities {
+		if s.Code == "WIKI/AAPL" {
+			fmt.Println(s.Ticker, s.Name, s.Sector, s.Industry)
+			fmt.Println(s.Sources[FieldName], s.Sources[FieldSector], s.Sources[FieldIndustry])
+		}
+	}
+
+	identifier, description := c.GetAllSecurityList()
+	fmt.Println(len(identifier), len(securities))
+	fmt.Printf("%q : %q\n", identifier[2], description[2])
+
+	// Output:
+	// AAPL Apple Inc. Information Technology Personal Computers
+	// WIKI_tickers.csv SP500.csv stockinfo.csv
+	// 12 12
+	// "GOOG/NYSE_A" : "Agilent Technologies"
+}
@@ -0,0 +1,438 @@
+package quandl
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// authHeader is the request header used to send the auth token when the
+// client is created with WithTokenInHeader. The v1 API only documents the
+// auth_token query parameter, so it is not the default.
+const authHeader = "X-Api-Token"
+
+// redacted replaces credentials in URLs, errors and log messages.
+const redacted = "REDACTED"
+
+// sensitiveParams are query parameters whose values are never printed.
+var sensitiveParams = []string{"auth_token", "api_key"}
+
+// Client retrieves data from the Quandl API. Create one with NewClient.
+// The package level functions use a shared default client.
+type Client struct {
+	authToken     string
+	tokenInHeader bool
+	httpClient    *http.Client
+	endpoints     Endpoints
+	endpointsSet  bool
+	listDir       string
+	cache         *diskCache
+	limiter       *rateLimiter
+	logger        *slog.Logger
+	hooks         []Hook
+	retries       int
+	backoff       time.Duration
+
+	catalogMu   sync.Mutex
+	catalog     *Catalog
+	searchIndex *SearchIndex
+
+	profile    string
+	configPath string
+	noConfig   bool
+
+	// configErr is why the default client could not read its config. It is
+	// returned by every request.
+	configErr error
+}
+
+// Option configures a Client created with NewClient.
+type Option func(*Client) error
+
+// NewClient creates a Client configured with the given options. Anything the
+// options leave unset is read from the environment and the config file: the
+// auth token from $QUANDL_API_KEY and then the selected profile, and the
+// endpoints, cache and rate limit from the profile. See Profile.
+func NewClient(options ...Option) (*Client, error) {
+	c := &Client{
+		httpClient: http.DefaultClient,
+		endpoints:  DefaultEndpoints(),
+		logger:     slog.New(slog.DiscardHandler),
+	}
+
+	for _, option := range options {
+		if err := option(c); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.resolveConfig(); err != nil {
+		return nil, err
+	}
+
+	if c.listDir != "" {
+		c.useListDir()
+	}
+
+	if c.authToken == "" {
+		c.logger.Warn("no auth token set, API calls are limited")
+	}
+
+	return c, nil
+}
+
+// WithAuthToken sets the auth token used for calls to the Quandl API.
+func WithAuthToken(token string) Option {
+	return func(c *Client) error {
+		c.authToken = token
+		return nil
+	}
+}
+
+// WithHTTPClient sets the http.Client used to make requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) error {
+		if httpClient == nil {
+			return errors.New("quandl: nil http client")
+		}
+		c.httpClient = httpClient
+		return nil
+	}
+}
+
+// WithLogger sends the client's diagnostics to logger. Without it the client
+// logs nothing. Requests are logged at debug level with the identifier,
+// redacted URL, status and latency; failures are logged at error level.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) error {
+		if logger == nil {
+			return errors.New("quandl: nil logger")
+		}
+		c.logger = logger
+		return nil
+	}
+}
+
+// WithTokenInQuery sends the auth token as the auth_token query parameter,
+// which is the default and the only way the v1 API documents. It undoes
+// WithTokenInHeader. The token is redacted from every error and log message.
+func WithTokenInQuery() Option {
+	return func(c *Client) error {
+		c.tokenInHeader = false
+		return nil
+	}
+}
+
+// WithTokenInHeader sends the auth token in the X-Api-Token request header
+// instead of the URL, for mirrors and later API versions that accept it.
+func WithTokenInHeader() Option {
+	return func(c *Client) error {
+		c.tokenInHeader = true
+		return nil
+	}
+}
+
+// String describes the client without ever printing the auth token.
+func (c *Client) String() string {
+	token := "none"
+	if c.authToken != "" {
+		token = redacted
+	}
+
+	return fmt.Sprintf("quandl.Client{apiRoot: %s, authToken: %s}", c.endpoints.APIRoot, token)
+}
+
+// GoString is the same as String so that %#v does not print the auth token.
+func (c *Client) GoString() string {
+	return c.String()
+}
+
+// GetData gets Quandl data for a particular identifier and a date range.
+func (c *Client) GetData(identifier string, startDate string, endDate string) (*QuandlResponse, error) {
+	params := url.Values{}
+	params.Set("trim_start", startDate)
+	params.Set("trim_end", endDate)
+
+	return c.getDataFromURL(identifier, c.datasetURL(identifier, params))
+}
+
+// GetAllHistory is similar to GetData except that it does not restrict a date range
+func (c *Client) GetAllHistory(identifier string) (*QuandlResponse, error) {
+	return c.getDataFromURL(identifier, c.datasetURL(identifier, url.Values{}))
+}
+
+// Search executes a query against the Quandl API and returns the JSON object
+// as a byte stream.
+func (c *Client) Search(query string) ([]byte, error) {
+	params := url.Values{}
+	params.Set("query", query)
+
+	return c.get(query, c.withAuth(c.endpoints.SearchRoot, params), true)
+}
+
+func (c *Client) datasetURL(identifier string, params url.Values) string {
+	return c.withAuth(c.endpoints.APIRoot+identifier+format, params)
+}
+
+// withAuth appends the query parameters to base, adding the auth token
+// unless the client sends it in a header.
+func (c *Client) withAuth(base string, params url.Values) string {
+	if !c.tokenInHeader && c.authToken != "" {
+		params.Set("auth_token", c.authToken)
+	}
+
+	if len(params) == 0 {
+		return base
+	}
+
+	return base + "?" + params.Encode()
+}
+
+// get retrieves rawURL, sending the auth token as a header when auth is set
+// and the client uses WithTokenInHeader. See open.
+func (c *Client) get(identifier string, rawURL string, auth bool) ([]byte, error) {
+	body, err := c.open(identifier, rawURL, auth)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// open starts retrieving rawURL and returns the body to be read as it
+// arrives. Unauthenticated URLs that are file paths are read from disk. The
+// identifier is only used for logging and hooks. A response is cached and
+// reported to the hooks once its body has been read to the end or closed.
+// Any error returned, including by the body, has credentials redacted.
+func (c *Client) open(identifier string, rawURL string, auth bool) (io.ReadCloser, error) {
+	if c.configErr != nil {
+		return nil, c.configErr
+	}
+
+	cacheKey := redactURL(rawURL)
+	logger := c.logger.With("identifier", identifier, "url", c.redact(rawURL))
+
+	// Lists can be read from local files, see WithListDir
+	if path, ok := localPath(rawURL); ok && !auth {
+		f, err := os.Open(path)
+		if err != nil {
+			logger.Error("quandl list read failed", "err", err)
+			return nil, err
+		}
+
+		logger.Debug("quandl list read")
+		return f, nil
+	}
+	req := Request{Identifier: identifier, URL: c.redact(rawURL)}
+
+	if c.cache != nil {
+		if body, ok := c.cache.get(cacheKey); ok {
+			req.Start = time.Now()
+			logger.Debug("quandl cache hit")
+			c.onCacheHit(req)
+			return ioutil.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	httpReq, err := http.NewRequest("GET", rawURL, nil)
+	if err != nil {
+		err = c.redactError(err)
+		logger.Error("quandl request failed", "err", err)
+		return nil, err
+	}
+
+	if auth && c.tokenInHeader && c.authToken != "" {
+		httpReq.Header.Set(authHeader, c.authToken)
+	}
+
+	for req.Attempt = 1; ; req.Attempt++ {
+		if c.limiter != nil {
+			c.limiter.wait()
+		}
+
+		req.Start = time.Now()
+		c.beforeRequest(req)
+		body, resp := c.do(httpReq)
+
+		if resp.Err == nil {
+			b := &responseBody{c: c, req: req, resp: resp, body: body, logger: logger, cacheKey: cacheKey}
+			if c.cache != nil {
+				b.buf = new(bytes.Buffer)
+			}
+			return b, nil
+		}
+
+		c.afterResponse(req, resp)
+		if req.Attempt > c.retries || !retryable(resp) {
+			logger.Error("quandl request failed", "status", resp.StatusCode, "latency", resp.Latency, "err", resp.Err)
+			return nil, resp.Err
+		}
+
+		delay := c.backoff << uint(req.Attempt-1)
+		logger.Warn("quandl request retrying", "status", resp.StatusCode, "attempt", req.Attempt, "delay", delay, "err", resp.Err)
+		c.onRetry(req, resp.Err, delay)
+		time.Sleep(delay)
+	}
+}
+
+// do makes a single attempt at the request. On success the body is left
+// for the caller to read and close.
+func (c *Client) do(req *http.Request) (io.ReadCloser, Response) {
+	start := time.Now()
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, Response{Latency: time.Since(start), Err: c.redactError(err)}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, Response{
+			StatusCode: resp.StatusCode,
+			Bytes:      len(body),
+			Latency:    time.Since(start),
+			Err:        fmt.Errorf("quandl: GET %s: %s", c.redact(req.URL.String()), resp.Status),
+		}
+	}
+
+	return resp.Body, Response{StatusCode: resp.StatusCode}
+}
+
+// responseBody is the body of a successful response. It counts, and with a
+// cache keeps, what is read, and finishes the response once.
+type responseBody struct {
+	c        *Client
+	req      Request
+	resp     Response
+	body     io.ReadCloser
+	logger   *slog.Logger
+	cacheKey string
+	buf      *bytes.Buffer // nil without a cache
+	done     bool
+}
+
+func (b *responseBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	b.resp.Bytes += n
+	if b.buf != nil {
+		b.buf.Write(p[:n])
+	}
+
+	switch {
+	case err == io.EOF:
+		b.finish(nil, true)
+	case err != nil:
+		err = b.c.redactError(err)
+		b.finish(err, false)
+	}
+
+	return n, err
+}
+
+// Close finishes the response, without caching a body that was not read to
+// the end.
+func (b *responseBody) Close() error {
+	b.finish(nil, false)
+	return b.body.Close()
+}
+
+// finish reports the response to the hooks and the log, and caches the body
+// if it is complete.
+func (b *responseBody) finish(err error, complete bool) {
+	if b.done {
+		return
+	}
+	b.done = true
+
+	b.resp.Latency = time.Since(b.req.Start)
+	b.resp.Err = err
+	b.c.afterResponse(b.req, b.resp)
+
+	if err != nil {
+		b.logger.Error("quandl request failed", "status", b.resp.StatusCode, "latency", b.resp.Latency, "err", err)
+		return
+	}
+	b.logger.Debug("quandl request", "status", b.resp.StatusCode, "latency", b.resp.Latency, "bytes", b.resp.Bytes)
+
+	if complete && b.buf != nil {
+		if err := b.c.cache.put(b.cacheKey, b.buf.Bytes()); err != nil {
+			b.logger.Warn("quandl cache write failed", "err", err)
+		}
+	}
+}
+
+// retryable reports whether a failed attempt might succeed if repeated.
+func retryable(resp Response) bool {
+	return resp.StatusCode == 0 || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// redactError removes the auth token and any sensitive query parameters from
+// err, including the URL carried by a *url.Error.
+func (c *Client) redactError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return &url.Error{Op: urlErr.Op, URL: c.redact(urlErr.URL), Err: c.redactError(urlErr.Err)}
+	}
+
+	if msg := c.redact(err.Error()); msg != err.Error() {
+		return errors.New(msg)
+	}
+
+	return err
+}
+
+// redact removes the auth token and sensitive query parameter values from s.
+func (c *Client) redact(s string) string {
+	s = redactURL(s)
+	if c.authToken != "" {
+		s = strings.Replace(s, c.authToken, redacted, -1)
+	}
+
+	return s
+}
+
+// redactURL replaces the values of sensitive query parameters in rawURL. It
+// works on any string containing a URL so it can be used on messages too.
+func redactURL(rawURL string) string {
+	for _, param := range sensitiveParams {
+		for start := 0; ; {
+			i := strings.Index(rawURL[start:], param+"=")
+			if i == -1 {
+				break
+			}
+			i += start + len(param) + 1
+
+			j := strings.IndexAny(rawURL[i:], "&# \"'")
+			if j == -1 {
+				j = len(rawURL)
+			} else {
+				j += i
+			}
+
+			rawURL = rawURL[:i] + redacted + rawURL[j:]
+			start = i + len(redacted)
+		}
+	}
+
+	return rawURL
+}
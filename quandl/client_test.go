@@ -0,0 +1,80 @@
+package quandl
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+)
+
+func ExampleClient_String() {
+	c, err := NewClient(WithoutConfig(), WithAuthToken("my-secret-token"))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(c)
+	fmt.Printf("%#v\n", c)
+
+	_, err = c.get("", "http://localhost:bad/api?auth_token=my-secret-token", true)
+	fmt.Println(err)
+
+	// Output:
+	// quandl.Client{apiRoot: https://www.quandl.com/api/v1/datasets/, authToken: REDACTED}
+	// quandl.Client{apiRoot: https://www.quandl.com/api/v1/datasets/, authToken: REDACTED}
+	// parse "http://localhost:bad/api?auth_token=REDACTED": invalid port ":bad" after host
+}
+
+func ExampleWithLogger() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	// Drop the time and latency and replace the server's random port so the
+	// output is repeatable
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			switch a.Key {
+			case slog.TimeKey, "latency":
+				return slog.Attr{}
+			case "url", "err":
+				return slog.String(a.Key, strings.Replace(a.Value.String(), server.URL, "http://mirror", -1))
+			}
+			return a
+		},
+	}))
+
+	c, err := NewClient(WithoutConfig(), WithAuthToken("my-secret-token"), WithTokenInQuery(), WithMirror(server.URL), WithLogger(logger))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	_, err = c.GetAllHistory("WIKI/NOPE")
+	fmt.Println(strings.Replace(err.Error(), server.URL, "http://mirror", -1))
+
+	// Output:
+	// level=ERROR msg="quandl request failed" identifier=WIKI/NOPE url="http://mirror/api/v1/datasets/WIKI/NOPE.json?auth_token=REDACTED" status=404 err="quandl: GET http://mirror/api/v1/datasets/WIKI/NOPE.json?auth_token=REDACTED: 404 Not Found"
+	// quandl: GET http://mirror/api/v1/datasets/WIKI/NOPE.json?auth_token=REDACTED: 404 Not Found
+}
+
+func ExampleWithTokenInHeader() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Printf("query %q, header %q\n", r.URL.Query().Get("auth_token"), r.Header.Get("X-Api-Token"))
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	// The v1 API reads the token from the query string by default
+	c, _ := NewClient(WithoutConfig(), WithAuthToken("my-secret-token"), WithMirror(server.URL))
+	c.GetAllHistory("WIKI/AAPL")
+
+	c, _ = NewClient(WithoutConfig(), WithAuthToken("my-secret-token"), WithMirror(server.URL), WithTokenInHeader())
+	c.GetAllHistory("WIKI/AAPL")
+
+	// Output:
+	// query "my-secret-token", header ""
+	// query "", header "my-secret-token"
+}
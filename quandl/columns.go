@@ -0,0 +1,245 @@
+package quandl
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// Field names used by the built in list mappings.
+const (
+	FieldCode       = "code"
+	FieldTicker     = "ticker"
+	FieldName       = "name"
+	FieldRatiosCode = "ratios_code"
+	FieldInMarket   = "in_market"
+	FieldExchange   = "exchange"
+	FieldSector     = "sector"
+	FieldIndustry   = "industry"
+	FieldSource     = "source"
+	FieldWeight     = "weight"
+	FieldAssetClass = "asset_class"
+	FieldActive     = "active"
+
+	FieldUnits              = "units"
+	FieldFrequency          = "frequency"
+	FieldSeasonalAdjustment = "seasonal_adjustment"
+	FieldLastUpdated        = "last_updated"
+)
+
+// Column binds a field to the list column whose header matches one of
+// Headers. Headers are compared ignoring case, spaces and punctuation, and
+// the first one present in the list is used.
+type Column struct {
+	Field    string
+	Headers  []string
+	Required bool
+}
+
+// Mapping describes the columns to read from a list.
+type Mapping []Column
+
+// Row is one record of a list, keyed by field name. Fields whose column is
+// not in the list, or is missing from a short row, are empty.
+type Row map[string]string
+
+// Header aliases shared by the built in mappings.
+var (
+	codeHeaders     = []string{"Quandl Code", "Code", "Price Code", "Free Code", "Dataset Code"}
+	tickerHeaders   = []string{"Ticker", "Symbol"}
+	nameHeaders     = []string{"Name", "Stock Name", "Company Name", "Company", "Security Name", "Fund Name", "Index Name", "Description"}
+	sectorHeaders   = []string{"Sector", "GICS Sector"}
+	industryHeaders = []string{"Industry", "GICS Sub Industry", "Sub Industry"}
+	weightHeaders   = []string{"Weight", "Index Weight", "Weighting"}
+)
+
+// Mappings for the Quandl lists.
+var (
+	wikiListMapping = Mapping{
+		{Field: FieldCode, Headers: codeHeaders, Required: true},
+		{Field: FieldName, Headers: nameHeaders},
+	}
+	stockListMapping = Mapping{
+		{Field: FieldTicker, Headers: tickerHeaders, Required: true},
+		{Field: FieldName, Headers: nameHeaders},
+		{Field: FieldCode, Headers: []string{"Price Code"}, Required: true},
+		{Field: FieldRatiosCode, Headers: []string{"Ratios Code"}},
+		{Field: FieldInMarket, Headers: []string{"In Market?", "Status"}},
+	}
+	codeListMapping = Mapping{
+		{Field: FieldTicker, Headers: tickerHeaders},
+		{Field: FieldCode, Headers: codeHeaders, Required: true},
+		{Field: FieldName, Headers: nameHeaders},
+	}
+	commoditiesListMapping = Mapping{
+		{Field: FieldCode, Headers: codeHeaders, Required: true},
+		{Field: FieldName, Headers: append([]string{"Commodity"}, nameHeaders...)},
+	}
+	currencyListMapping = Mapping{
+		{Field: FieldCode, Headers: codeHeaders, Required: true},
+		{Field: FieldName, Headers: append([]string{"Currency"}, nameHeaders...)},
+		{Field: FieldSource, Headers: []string{"Source", "Source Code"}},
+	}
+	stockInfoListMapping = Mapping{
+		{Field: FieldTicker, Headers: tickerHeaders, Required: true},
+		{Field: FieldName, Headers: nameHeaders},
+		{Field: FieldExchange, Headers: []string{"Exchange"}},
+		{Field: FieldSector, Headers: sectorHeaders},
+		{Field: FieldIndustry, Headers: industryHeaders},
+	}
+	economicListMapping = Mapping{
+		{Field: FieldCode, Headers: append([]string{"Series ID"}, codeHeaders...), Required: true},
+		{Field: FieldName, Headers: append([]string{"Title"}, nameHeaders...)},
+		{Field: FieldUnits, Headers: []string{"Units"}},
+		{Field: FieldFrequency, Headers: []string{"Frequency"}},
+		{Field: FieldSeasonalAdjustment, Headers: []string{"Seasonal Adjustment", "Seasonally Adjusted", "SA"}},
+		{Field: FieldLastUpdated, Headers: []string{"Last Updated"}},
+	}
+	constituentListMapping = Mapping{
+		{Field: FieldTicker, Headers: tickerHeaders, Required: true},
+		{Field: FieldName, Headers: nameHeaders},
+		{Field: FieldSector, Headers: sectorHeaders},
+		{Field: FieldIndustry, Headers: industryHeaders},
+		{Field: FieldWeight, Headers: weightHeaders},
+	}
+	ftse100ListMapping = Mapping{
+		{Field: FieldTicker, Headers: tickerHeaders},
+		{Field: FieldCode, Headers: codeHeaders, Required: true},
+		{Field: FieldName, Headers: nameHeaders},
+		{Field: FieldSector, Headers: sectorHeaders},
+		{Field: FieldIndustry, Headers: industryHeaders},
+		{Field: FieldWeight, Headers: weightHeaders},
+	}
+)
+
+// LoadList loads the list at url and binds its columns by header name
+func LoadList(url string, mapping Mapping) ([]Row, error) {
+	return defaultClient().LoadList(url, mapping)
+}
+
+// LoadList loads the list at url and binds its columns by header name. Rows
+// are bound as the list arrives.
+func (c *Client) LoadList(url string, mapping Mapping) ([]Row, error) {
+	body, err := c.openList(url)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	list, err := bindList(NewListReader(body), mapping)
+	if err != nil {
+		return nil, fmt.Errorf("quandl: %s: %v", c.redact(url), err)
+	}
+
+	return list, nil
+}
+
+// ParseList reads a delimited list from r, see ListReader, and binds its
+// columns by header name.
+func ParseList(r io.Reader, mapping Mapping) ([]Row, error) {
+	return bindList(NewListReader(r), mapping)
+}
+
+// bindList reads the header row and converts each record after it into a
+// row as it is read. It fails if a required column is missing from the
+// header.
+func bindList(r *ListReader, mapping Mapping) ([]Row, error) {
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("list is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := bindColumns(header, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []Row{}
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return list, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		row := make(Row, len(columns))
+		for name, i := range columns {
+			row[name] = field(record, i)
+		}
+		list = append(list, row)
+	}
+}
+
+// bindColumns returns the column number of each field in the mapping that is
+// present in header.
+func bindColumns(header []string, mapping Mapping) (map[string]int, error) {
+	positions := make(map[string]int, len(header))
+	for i, h := range header {
+		key := normalizeHeader(h)
+		if _, ok := positions[key]; !ok {
+			positions[key] = i
+		}
+	}
+
+	columns := make(map[string]int, len(mapping))
+	var missing []string
+
+	for _, column := range mapping {
+		for _, h := range column.Headers {
+			if i, ok := positions[normalizeHeader(h)]; ok {
+				columns[column.Field] = i
+				break
+			}
+		}
+
+		if _, ok := columns[column.Field]; !ok && column.Required {
+			missing = append(missing, fmt.Sprintf("%s (one of %q)", column.Field, column.Headers))
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing required columns %s in header %q", strings.Join(missing, ", "), header)
+	}
+
+	return columns, nil
+}
+
+// normalizeHeader lower cases h and drops everything but letters and digits,
+// so "In Market?" and "in_market" match.
+func normalizeHeader(h string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, h)
+}
+
+// field returns column i of record, or "" if the record is too short.
+func field(record []string, i int) string {
+	if i < 0 || i >= len(record) {
+		return ""
+	}
+
+	return strings.TrimSpace(record[i])
+}
+
+// fields returns the two fields of every row as parallel slices, for the
+// functions that predate Row.
+func fields(list []Row, field1 string, field2 string) ([]string, []string) {
+	first := make([]string, len(list))
+	second := make([]string, len(list))
+
+	for i, row := range list {
+		first[i] = row[field1]
+		second[i] = row[field2]
+	}
+
+	return first, second
+}
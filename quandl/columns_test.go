@@ -0,0 +1,23 @@
+package quandl
+
+import (
+	"fmt"
+	"strings"
+)
+
+func ExampleParseList() {
+	mapping := Mapping{
+		{Field: "code", Headers: []string{"Quandl Code", "Code"}, Required: true},
+		{Field: "name", Headers: []string{"Name", "Description"}},
+	}
+
+	list, err := ParseList(strings.NewReader("Description,quandl_code\nApple Inc.,WIKI/AAPL\n"), mapping)
+	fmt.Println(list, err)
+
+	_, err = ParseList(strings.NewReader("Ticker,Name\nAAPL,Apple Inc.\n"), mapping)
+	fmt.Println(err)
+
+	// Output:
+	// [map[code:WIKI/AAPL name:Apple Inc.]] <nil>
+	// missing required columns code (one of ["Quandl Code" "Code"]) in header ["Ticker" "Name"]
+}
@@ -0,0 +1,297 @@
+package quandl
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Environment variables read when a client is created.
+const (
+	// EnvAPIKey holds the auth token when none is passed to NewClient.
+	EnvAPIKey = "QUANDL_API_KEY"
+	// EnvProfile names the config file profile to use.
+	EnvProfile = "QUANDL_PROFILE"
+	// EnvConfigFile overrides the location of the config file.
+	EnvConfigFile = "QUANDL_CONFIG_FILE"
+)
+
+// DefaultProfile is the profile used when none is named.
+const DefaultProfile = "default"
+
+// Profile is one named section of the config file, e.g.
+//
+//	[research]
+//	api_key    = abc123
+//	base_url   = https://quandl-mirror.internal
+//	cache_dir  = ~/.cache/quandl/research
+//	cache_ttl  = 24h
+//	rate_limit = 2
+type Profile struct {
+	Name       string
+	APIKey     string
+	BaseURL    string // root of a mirror, see MirrorEndpoints
+	APIRoot    string
+	SearchRoot string
+	Proxy      string
+	CacheDir   string
+	CacheTTL   time.Duration
+	RateLimit  float64 // requests per second
+}
+
+// DefaultConfigPath returns the location of the config file:
+// $QUANDL_CONFIG_FILE, else $XDG_CONFIG_HOME/quandl/config, else
+// ~/.config/quandl/config.
+func DefaultConfigPath() (string, error) {
+	if path := os.Getenv(EnvConfigFile); path != "" {
+		return path, nil
+	}
+
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "quandl", "config"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".config", "quandl", "config"), nil
+}
+
+// LoadConfig reads the profiles in the config file at path.
+func LoadConfig(path string) (map[string]Profile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	profiles, err := ParseConfig(f)
+	if err != nil {
+		return nil, fmt.Errorf("quandl: %s: %v", path, err)
+	}
+
+	return profiles, nil
+}
+
+// ParseConfig reads profiles from an INI style config file. Each profile
+// starts with a [name] line followed by key = value lines. Lines starting
+// with # or ; are comments. Keys before the first section belong to the
+// default profile.
+func ParseConfig(r io.Reader) (map[string]Profile, error) {
+	profiles := make(map[string]Profile)
+	current := DefaultProfile
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, ";"):
+			continue
+		case strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]"):
+			current = strings.TrimSpace(text[1 : len(text)-1])
+			if current == "" {
+				return nil, fmt.Errorf("line %d: empty profile name", line)
+			}
+			if _, ok := profiles[current]; !ok {
+				profiles[current] = Profile{Name: current}
+			}
+			continue
+		}
+
+		i := strings.IndexAny(text, "=:")
+		if i == -1 {
+			return nil, fmt.Errorf("line %d: expected key = value", line)
+		}
+
+		key := strings.ToLower(strings.TrimSpace(text[:i]))
+		value := strings.Trim(strings.TrimSpace(text[i+1:]), `"'`)
+
+		profile := profiles[current]
+		profile.Name = current
+		if err := profile.set(key, value); err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		profiles[current] = profile
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return profiles, nil
+}
+
+func (p *Profile) set(key string, value string) error {
+	var err error
+
+	switch key {
+	case "api_key", "auth_token":
+		p.APIKey = value
+	case "base_url", "mirror":
+		p.BaseURL = value
+	case "api_root":
+		p.APIRoot = value
+	case "search_root":
+		p.SearchRoot = value
+	case "proxy":
+		p.Proxy = value
+	case "cache_dir":
+		p.CacheDir, err = expandHome(value)
+	case "cache_ttl":
+		p.CacheTTL, err = time.ParseDuration(value)
+	case "rate_limit":
+		p.RateLimit, err = strconv.ParseFloat(value, 64)
+	default:
+		err = fmt.Errorf("unknown key %q", key)
+	}
+
+	return err
+}
+
+// String describes the profile without printing the API key.
+func (p Profile) String() string {
+	key := "none"
+	if p.APIKey != "" {
+		key = redacted
+	}
+
+	return fmt.Sprintf("quandl.Profile{Name: %s, APIKey: %s, BaseURL: %s, CacheDir: %s, RateLimit: %v}",
+		p.Name, key, p.BaseURL, p.CacheDir, p.RateLimit)
+}
+
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, path[1:]), nil
+}
+
+// WithProfile selects a profile from the config file. Without it the
+// profile named by $QUANDL_PROFILE is used, falling back to "default".
+func WithProfile(name string) Option {
+	return func(c *Client) error {
+		c.profile = name
+		return nil
+	}
+}
+
+// WithConfigFile reads profiles from path instead of DefaultConfigPath.
+func WithConfigFile(path string) Option {
+	return func(c *Client) error {
+		c.configPath = path
+		return nil
+	}
+}
+
+// WithoutConfig stops the client reading the environment and config file, so
+// only the options passed to NewClient are used.
+func WithoutConfig() Option {
+	return func(c *Client) error {
+		c.noConfig = true
+		return nil
+	}
+}
+
+// resolveConfig fills in anything the options did not set. The auth token
+// comes from $QUANDL_API_KEY and then the selected profile; endpoints, cache
+// and rate limit come from the profile.
+func (c *Client) resolveConfig() error {
+	if c.noConfig {
+		return nil
+	}
+
+	profile, err := c.loadProfile()
+	if err != nil {
+		return err
+	}
+
+	if c.authToken == "" {
+		c.authToken = os.Getenv(EnvAPIKey)
+	}
+	if c.authToken == "" {
+		c.authToken = profile.APIKey
+	}
+
+	if !c.endpointsSet {
+		if profile.BaseURL != "" {
+			c.endpoints = MirrorEndpoints(profile.BaseURL)
+		}
+		if profile.APIRoot != "" {
+			c.endpoints.APIRoot = profile.APIRoot
+		}
+		if profile.SearchRoot != "" {
+			c.endpoints.SearchRoot = profile.SearchRoot
+		}
+	}
+
+	if profile.Proxy != "" && c.httpClient == http.DefaultClient {
+		if err := WithProxy(profile.Proxy)(c); err != nil {
+			return err
+		}
+	}
+
+	if c.cache == nil && profile.CacheDir != "" {
+		if err := WithCache(profile.CacheDir, profile.CacheTTL)(c); err != nil {
+			return err
+		}
+	}
+
+	if c.limiter == nil && profile.RateLimit > 0 {
+		if err := WithRateLimit(profile.RateLimit)(c); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadProfile returns the selected profile. A missing config file is only an
+// error when it was named explicitly, and a missing profile is only an error
+// when it was not the default.
+func (c *Client) loadProfile() (Profile, error) {
+	name := c.profile
+	if name == "" {
+		name = os.Getenv(EnvProfile)
+	}
+	if name == "" {
+		name = DefaultProfile
+	}
+
+	path := c.configPath
+	explicit := path != "" || os.Getenv(EnvConfigFile) != ""
+	if path == "" {
+		var err error
+		if path, err = DefaultConfigPath(); err != nil {
+			return Profile{}, nil
+		}
+	}
+
+	profiles, err := LoadConfig(path)
+	if os.IsNotExist(err) && !explicit {
+		profiles = nil
+	} else if err != nil {
+		return Profile{}, err
+	}
+
+	profile, ok := profiles[name]
+	if !ok && name != DefaultProfile {
+		return Profile{}, fmt.Errorf("quandl: profile %q not found in %s", name, path)
+	}
+
+	return profile, nil
+}
@@ -0,0 +1,78 @@
+package quandl
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func ExampleParseConfig() {
+	profiles, err := ParseConfig(strings.NewReader(`
+# Shared Quandl settings
+[default]
+api_key = abc123
+
+[research]
+api_key    = def456
+base_url   = https://quandl-mirror.internal
+cache_ttl  = 24h
+rate_limit = 2
+`))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(profiles["default"])
+	fmt.Println(profiles["research"])
+	fmt.Println(MirrorEndpoints(profiles["research"].BaseURL).ETFList)
+
+	// Output:
+	// quandl.Profile{Name: default, APIKey: REDACTED, BaseURL: , CacheDir: , RateLimit: 0}
+	// quandl.Profile{Name: research, APIKey: REDACTED, BaseURL: https://quandl-mirror.internal, CacheDir: , RateLimit: 2}
+	// https://quandl-mirror.internal/Ticker+CSV%27s/ETFs.csv
+}
+
+func ExampleWithConfigFile() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Println(r.URL.Query().Get("auth_token"))
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	dir, _ := os.MkdirTemp("", "config")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config")
+	os.WriteFile(path, []byte("[default]\napi_key = from-profile\n"), 0600)
+
+	if key, ok := os.LookupEnv(EnvAPIKey); ok {
+		defer os.Setenv(EnvAPIKey, key)
+	} else {
+		defer os.Unsetenv(EnvAPIKey)
+	}
+
+	// An option beats $QUANDL_API_KEY, which beats the profile
+	get := func(options ...Option) {
+		options = append(options, WithConfigFile(path), WithProfile(DefaultProfile), WithMirror(server.URL))
+		c, err := NewClient(options...)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		c.GetAllHistory("WIKI/AAPL")
+	}
+
+	os.Unsetenv(EnvAPIKey)
+	get()
+	os.Setenv(EnvAPIKey, "from-env")
+	get()
+	get(WithAuthToken("from-option"))
+
+	// Output:
+	// from-profile
+	// from-env
+	// from-option
+}
@@ -0,0 +1,184 @@
+package quandl
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Index is a stock index Quandl publishes a constituent list for.
+type Index int
+
+const (
+	SP500 Index = iota + 1
+	DowJones
+	NasdaqComposite
+	Nasdaq100
+	FTSE100
+)
+
+// Indices are the supported indices.
+var Indices = []Index{SP500, DowJones, NasdaqComposite, Nasdaq100, FTSE100}
+
+var indexNames = map[Index]string{
+	SP500:           "S&P 500",
+	DowJones:        "Dow Jones Industrial Average",
+	NasdaqComposite: "NASDAQ Composite",
+	Nasdaq100:       "NASDAQ-100",
+	FTSE100:         "FTSE 100",
+}
+
+func (i Index) String() string {
+	if name, ok := indexNames[i]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("Index(%d)", int(i))
+}
+
+// list returns the URL of the index's constituent list and its mapping.
+func (i Index) list(e Endpoints) (string, Mapping, error) {
+	switch i {
+	case SP500:
+		return e.SP500Constituents, constituentListMapping, nil
+	case DowJones:
+		return e.DowConstituents, constituentListMapping, nil
+	case NasdaqComposite:
+		return e.NasdaqCompositeConstituents, constituentListMapping, nil
+	case Nasdaq100:
+		return e.Nasdaq100Constituents, constituentListMapping, nil
+	case FTSE100:
+		return e.FTSE100Constituents, ftse100ListMapping, nil
+	}
+
+	return "", nil, fmt.Errorf("quandl: unknown index %v", i)
+}
+
+// Constituent is a member of an index. Fields the list does not have are
+// empty, and Weight is 0.
+type Constituent struct {
+	Index    Index
+	Code     string // Quandl code, e.g. WIKI/AAPL
+	Ticker   string
+	Name     string
+	Sector   string
+	Industry string
+	Weight   float64 // fraction of the index, e.g. 0.035 for 3.5%
+}
+
+// Security converts the constituent to a catalog Security.
+func (m Constituent) Security() Security {
+	return Security{
+		Code:       m.Code,
+		Ticker:     m.Ticker,
+		Name:       m.Name,
+		AssetClass: Stock,
+		Exchange:   exchangeFromCode(m.Code),
+		Sector:     m.Sector,
+		Industry:   m.Industry,
+	}
+}
+
+// GetIndexConstituents gets the members of index
+func GetIndexConstituents(index Index) ([]Constituent, error) {
+	return defaultClient().GetIndexConstituents(index)
+}
+
+// GetIndexConstituents gets the members of index
+func (c *Client) GetIndexConstituents(index Index) ([]Constituent, error) {
+	url, mapping, err := index.list(c.endpoints)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := c.LoadList(url, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseConstituents(index, list), nil
+}
+
+// ParseIndexConstituents reads a list in the format used by
+// GetIndexConstituents for index
+func ParseIndexConstituents(index Index, r io.Reader) ([]Constituent, error) {
+	_, mapping, err := index.list(DefaultEndpoints())
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := ParseList(r, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseConstituents(index, list), nil
+}
+
+// parseConstituents converts a constituent list. US lists give tickers,
+// which are traded under WIKI codes, while the FTSE 100 list gives codes.
+func parseConstituents(index Index, list []Row) []Constituent {
+	scale := weightScale(list)
+
+	var members []Constituent
+	for _, r := range list {
+		m := Constituent{
+			Index:    index,
+			Code:     r[FieldCode],
+			Ticker:   r[FieldTicker],
+			Name:     r[FieldName],
+			Sector:   r[FieldSector],
+			Industry: r[FieldIndustry],
+			Weight:   parseWeight(r[FieldWeight]) * scale,
+		}
+
+		if m.Code == "" && m.Ticker != "" {
+			m.Code = "WIKI/" + m.Ticker
+		}
+		if m.Ticker == "" {
+			m.Ticker = tickerFromCode(m.Code)
+		}
+
+		members = append(members, m)
+	}
+
+	return members
+}
+
+// maxFractionTotal is the most a list of weights given as fractions can add
+// up to, allowing for rounding and lists that are out of date.
+const maxFractionTotal = 1.5
+
+// weightScale decides once for the whole list whether its weights are
+// fractions or percentages, so that 0.45 in a list of percentages is not
+// read as 45%. They are percentages if any has a % sign or they add up to
+// more than maxFractionTotal, since fractions add up to at most 1.
+func weightScale(list []Row) float64 {
+	var total float64
+	for _, r := range list {
+		if strings.HasSuffix(strings.TrimSpace(r[FieldWeight]), "%") {
+			return 0.01
+		}
+		total += parseWeight(r[FieldWeight])
+	}
+
+	if total > maxFractionTotal {
+		return 0.01
+	}
+
+	return 1
+}
+
+// parseWeight reads a weight, ignoring any % sign. weightScale gives the
+// scale of the list it comes from.
+func parseWeight(s string) float64 {
+	s = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), "%"))
+
+	w, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+
+	return w
+}
@@ -0,0 +1,61 @@
+package quandl
+
+import (
+	"fmt"
+	"strings"
+)
+
+func ExampleGetIndexConstituents() {
+	mirror := newMirror(mirrorFiles)
+	defer mirror.Close()
+
+	c, err := NewClient(WithoutConfig(), WithMirror(mirror.URL))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	members, err := c.GetIndexConstituents(SP500)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for _, m := range members {
+		fmt.Printf("%s: %s %s %q %q\n", m.Index, m.Code, m.Ticker, m.Name, m.Sector)
+	}
+
+	// Output:
+	// S&P 500: WIKI/AAPL AAPL "Apple Inc." "Information Technology"
+}
+
+func ExampleParseIndexConstituents() {
+	ftse := "Code,Name,Sector,Weight\nGOOG/LON_HSBA,HSBC Holdings,Financials,6.5%\nGOOG/LON_BP,BP,Oil & Gas,5.2\n"
+
+	members, err := ParseIndexConstituents(FTSE100, strings.NewReader(ftse))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for _, m := range members {
+		fmt.Printf("%s %s %s %.3f\n", m.Code, m.Ticker, m.Security().Exchange, m.Weight)
+	}
+
+	// Weights are read as percentages because they add up to more than 1,
+	// so 0.45 is 0.45% rather than 45%
+	percents := "Code,Name,Weight\nGOOG/LON_HSBA,HSBC Holdings,3.5\nGOOG/LON_VOD,Vodafone,0.45\n"
+	members, _ = ParseIndexConstituents(FTSE100, strings.NewReader(percents))
+	for _, m := range members {
+		fmt.Printf("%s %.4f\n", m.Code, m.Weight)
+	}
+
+	_, err = ParseIndexConstituents(Index(42), strings.NewReader(ftse))
+	fmt.Println(err)
+
+	// Output:
+	// GOOG/LON_HSBA HSBA LON 0.065
+	// GOOG/LON_BP BP LON 0.052
+	// GOOG/LON_HSBA 0.0350
+	// GOOG/LON_VOD 0.0045
+	// quandl: unknown index Index(42)
+}
@@ -0,0 +1,282 @@
+package quandl
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Frequency is how often a series is observed.
+type Frequency int
+
+const (
+	UnknownFrequency Frequency = iota
+	Daily
+	Weekly
+	Biweekly
+	Monthly
+	Quarterly
+	SemiAnnual
+	Annual
+)
+
+var frequencyNames = []string{"Unknown", "Daily", "Weekly", "Biweekly", "Monthly", "Quarterly", "Semiannual", "Annual"}
+
+func (f Frequency) String() string {
+	if f < 0 || int(f) >= len(frequencyNames) {
+		return fmt.Sprintf("Frequency(%d)", int(f))
+	}
+
+	return frequencyNames[f]
+}
+
+// frequencyCodes are the short frequency codes FRED uses.
+var frequencyCodes = map[string]Frequency{
+	"d": Daily, "w": Weekly, "bw": Biweekly, "m": Monthly, "q": Quarterly, "sa": SemiAnnual, "a": Annual,
+}
+
+// ParseFrequency parses a frequency such as "Monthly", "Weekly, Ending
+// Friday", "annual" or one of the FRED codes D, W, BW, M, Q, SA and A.
+func ParseFrequency(s string) Frequency {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if f, ok := frequencyCodes[s]; ok {
+		return f
+	}
+
+	return frequencyFromWord(s)
+}
+
+// frequencyFromWord returns the frequency s starts with, leaving out the
+// short codes so that it can be used on words taken from a title.
+func frequencyFromWord(s string) Frequency {
+	s = strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(s))
+
+	for f := Annual; f > UnknownFrequency; f-- {
+		if strings.HasPrefix(s, strings.ToLower(frequencyNames[f])) {
+			return f
+		}
+	}
+
+	return UnknownFrequency
+}
+
+// seasonalAdjustments expands the short codes FRED uses.
+var seasonalAdjustments = map[string]string{
+	"sa":   "Seasonally Adjusted",
+	"nsa":  "Not Seasonally Adjusted",
+	"saar": "Seasonally Adjusted Annual Rate",
+}
+
+// EconomicSeries is one FRED series from the Quandl economic data list.
+// Fields the list does not have are parsed from the title where possible.
+type EconomicSeries struct {
+	Code               string // Quandl code, e.g. FRED/LAUR
+	ID                 string // FRED series ID, e.g. LAUR
+	Title              string
+	Units              string
+	Frequency          Frequency
+	SeasonalAdjustment string // e.g. Seasonally Adjusted
+	Geography          string // e.g. Louisiana for "Unemployment Rate in Louisiana"
+	LastUpdated        string
+}
+
+// SeasonallyAdjusted reports whether the series is seasonally adjusted.
+func (s EconomicSeries) SeasonallyAdjusted() bool {
+	return strings.HasPrefix(s.SeasonalAdjustment, "Seasonally Adjusted")
+}
+
+// EconomicSeriesList is the FRED series Quandl has data for.
+type EconomicSeriesList []EconomicSeries
+
+// ByFrequency returns the series observed at frequency f.
+func (l EconomicSeriesList) ByFrequency(f Frequency) EconomicSeriesList {
+	return l.filter(func(s EconomicSeries) bool {
+		return s.Frequency == f
+	})
+}
+
+// ByRegion returns the series whose geography is region, ignoring case. A
+// region also matches any comma separated part of a geography, so CA matches
+// "Los Angeles County, CA".
+func (l EconomicSeriesList) ByRegion(region string) EconomicSeriesList {
+	region = strings.TrimSpace(region)
+
+	return l.filter(func(s EconomicSeries) bool {
+		if strings.EqualFold(s.Geography, region) {
+			return true
+		}
+		for _, part := range strings.Split(s.Geography, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), region) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Matching returns the series whose title or units contain every word of
+// keyword, ignoring case.
+func (l EconomicSeriesList) Matching(keyword string) EconomicSeriesList {
+	words := tokenize(keyword)
+
+	return l.filter(func(s EconomicSeries) bool {
+		terms := make(map[string]bool)
+		for _, term := range tokenize(s.Title + " " + s.Units) {
+			terms[term] = true
+		}
+		for _, word := range words {
+			if !terms[word] {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func (l EconomicSeriesList) filter(keep func(EconomicSeries) bool) EconomicSeriesList {
+	var filtered EconomicSeriesList
+	for _, s := range l {
+		if keep(s) {
+			filtered = append(filtered, s)
+		}
+	}
+
+	return filtered
+}
+
+// GetEconomicSeries gets every series in the FRED list with all of its
+// columns
+func GetEconomicSeries() (EconomicSeriesList, error) {
+	return defaultClient().GetEconomicSeries()
+}
+
+// GetEconomicSeries gets every series in the FRED list with all of its
+// columns
+func (c *Client) GetEconomicSeries() (EconomicSeriesList, error) {
+	list, err := c.LoadList(c.endpoints.EconomicData, economicListMapping)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseEconomicSeries(list), nil
+}
+
+// ParseEconomicSeries reads a list in the format used by GetEconomicSeries
+func ParseEconomicSeries(r io.Reader) (EconomicSeriesList, error) {
+	list, err := ParseList(r, economicListMapping)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseEconomicSeries(list), nil
+}
+
+func parseEconomicSeries(list []Row) EconomicSeriesList {
+	var series EconomicSeriesList
+	for _, r := range list {
+		id := strings.TrimPrefix(r[FieldCode], "FRED/")
+		title := r[FieldName]
+
+		s := EconomicSeries{
+			Code:               "FRED/" + id,
+			ID:                 id,
+			Title:              title,
+			Units:              r[FieldUnits],
+			Frequency:          ParseFrequency(r[FieldFrequency]),
+			SeasonalAdjustment: parseSeasonalAdjustment(r[FieldSeasonalAdjustment]),
+			LastUpdated:        r[FieldLastUpdated],
+		}
+
+		// Some lists put the frequency and adjustment at the end of the title,
+		// e.g. "Unemployment Rate in Louisiana, Seasonally Adjusted, Monthly"
+		parts := strings.Split(title, ",")
+		for len(parts) > 1 {
+			part := strings.TrimSpace(parts[len(parts)-1])
+			if f := frequencyFromWord(part); f != UnknownFrequency {
+				if s.Frequency == UnknownFrequency {
+					s.Frequency = f
+				}
+			} else if strings.HasSuffix(strings.ToLower(part), "seasonally adjusted") {
+				if s.SeasonalAdjustment == "" {
+					s.SeasonalAdjustment = parseSeasonalAdjustment(part)
+				}
+			} else {
+				break
+			}
+			parts = parts[:len(parts)-1]
+		}
+		s.Geography = geographyFromTitle(strings.Join(parts, ","))
+
+		series = append(series, s)
+	}
+
+	return series
+}
+
+// parseSeasonalAdjustment expands the FRED short codes and leaves anything
+// else as given.
+func parseSeasonalAdjustment(s string) string {
+	s = strings.TrimSpace(s)
+	if long, ok := seasonalAdjustments[strings.ToLower(s)]; ok {
+		return long
+	}
+
+	return s
+}
+
+// geographyFromTitle returns the place after the last " in " or " for " of
+// a FRED title, e.g. Louisiana from "Unemployment Rate in Louisiana" or "Los
+// Angeles County, CA" from "Unemployment Rate in Los Angeles County, CA".
+// Only the United States, its states and places ending in a state code are
+// recognised, since FRED titles capitalise every word and "Change in Private
+// Inventories" is not a place.
+func geographyFromTitle(title string) string {
+	i := max(strings.LastIndex(title, " in "), strings.LastIndex(title, " for "))
+	if i < 0 {
+		return ""
+	}
+
+	place := title[i+1:]
+	place = place[strings.Index(place, " ")+1:]
+	place = strings.TrimPrefix(place, "the ")
+	if j := strings.Index(place, " ("); j >= 0 {
+		place = place[:j]
+	}
+	place = strings.TrimSpace(place)
+
+	if usStates[place] || place == "United States" || place == "U.S." {
+		return place
+	}
+	if j := strings.LastIndex(place, ", "); j > 0 && isStateCode(place[j+2:]) {
+		return place
+	}
+
+	return ""
+}
+
+// usStates are the places FRED publishes state level series for.
+var usStates = map[string]bool{
+	"Alabama": true, "Alaska": true, "Arizona": true, "Arkansas": true, "California": true,
+	"Colorado": true, "Connecticut": true, "Delaware": true, "District of Columbia": true,
+	"Florida": true, "Georgia": true, "Hawaii": true, "Idaho": true, "Illinois": true,
+	"Indiana": true, "Iowa": true, "Kansas": true, "Kentucky": true, "Louisiana": true,
+	"Maine": true, "Maryland": true, "Massachusetts": true, "Michigan": true, "Minnesota": true,
+	"Mississippi": true, "Missouri": true, "Montana": true, "Nebraska": true, "Nevada": true,
+	"New Hampshire": true, "New Jersey": true, "New Mexico": true, "New York": true,
+	"North Carolina": true, "North Dakota": true, "Ohio": true, "Oklahoma": true, "Oregon": true,
+	"Pennsylvania": true, "Puerto Rico": true, "Rhode Island": true, "South Carolina": true,
+	"South Dakota": true, "Tennessee": true, "Texas": true, "Utah": true, "Vermont": true,
+	"Virginia": true, "Washington": true, "West Virginia": true, "Wisconsin": true, "Wyoming": true,
+}
+
+// isStateCode reports whether s looks like a state code or a run of them,
+// as in "New York-Newark-Jersey City, NY-NJ-PA".
+func isStateCode(s string) bool {
+	for _, code := range strings.Split(s, "-") {
+		if len(code) != 2 || code[0] < 'A' || code[0] > 'Z' || code[1] < 'A' || code[1] > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
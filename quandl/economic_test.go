@@ -0,0 +1,56 @@
+package quandl
+
+import (
+	"fmt"
+	"strings"
+)
+
+const fredSnapshot = `Series ID|Title|Units|Frequency|Seasonal Adjustment|Last Updated
+GDP|Gross Domestic Product|Billions of Dollars|Quarterly|SAAR|2016-03-25
+UNRATE|Civilian Unemployment Rate|Percent|Monthly|SA|2016-04-01
+LAUR|Unemployment Rate in Louisiana|Percent|Monthly|SA|2016-03-28
+LAURN|Unemployment Rate in Louisiana|Percent|Monthly|NSA|2016-03-28
+LOSA106UR|Unemployment Rate in Los Angeles County, CA|Percent|M|NSA|2016-03-30
+CBI|Change in Private Inventories|Billions of Dollars|Quarterly|SAAR|2016-03-25
+`
+
+func ExampleEconomicSeriesList() {
+	series, err := ParseEconomicSeries(strings.NewReader(fredSnapshot))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for _, s := range series.Matching("unemployment rate").ByFrequency(Monthly) {
+		fmt.Printf("%s %q %s %s %v\n", s.Code, s.Geography, s.Units, s.Frequency, s.SeasonallyAdjusted())
+	}
+	for _, s := range series.ByRegion("CA") {
+		fmt.Println(s.Code, s.Title)
+	}
+	for _, s := range series.ByRegion("louisiana") {
+		fmt.Println(s.Code, s.SeasonalAdjustment)
+	}
+
+	// Output:
+	// FRED/UNRATE "" Percent Monthly true
+	// FRED/LAUR "Louisiana" Percent Monthly true
+	// FRED/LAURN "Louisiana" Percent Monthly false
+	// FRED/LOSA106UR "Los Angeles County, CA" Percent Monthly false
+	// FRED/LOSA106UR Unemployment Rate in Los Angeles County, CA
+	// FRED/LAUR Seasonally Adjusted
+	// FRED/LAURN Not Seasonally Adjusted
+}
+
+func ExampleParseFrequency() {
+	for _, s := range []string{"Monthly", "Weekly, Ending Friday", "Q", "Semiannual", "A", "hourly"} {
+		fmt.Println(ParseFrequency(s))
+	}
+
+	// Output:
+	// Monthly
+	// Weekly
+	// Quarterly
+	// Semiannual
+	// Annual
+	// Unknown
+}
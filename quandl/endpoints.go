@@ -0,0 +1,138 @@
+package quandl
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// staticContentRoot is the prefix shared by every ticker list file.
+const staticContentRoot = "https://s3.amazonaws.com/quandl-static-content/"
+
+// Endpoints holds every URL a Client reads from. Start from DefaultEndpoints
+// or MirrorEndpoints and override individual fields as needed.
+type Endpoints struct {
+	APIRoot    string
+	SearchRoot string
+
+	StockList       string
+	StockWikiList   string
+	StockInfoList   string
+	ETFList         string
+	StockIndexList  string
+	MutualFundList  string
+	CommoditiesList string
+	CurrencyList    string
+	EconomicData    string
+
+	SP500Constituents           string
+	DowConstituents             string
+	NasdaqCompositeConstituents string
+	Nasdaq100Constituents       string
+	FTSE100Constituents         string
+}
+
+// DefaultEndpoints returns the public Quandl API and S3 list URLs.
+func DefaultEndpoints() Endpoints {
+	return Endpoints{
+		APIRoot:    quandlApiRoot,
+		SearchRoot: quandlSearchRoot,
+
+		StockList:       quandlStockList,
+		StockWikiList:   quandlStockWikiList,
+		StockInfoList:   sectorList,
+		ETFList:         etfList,
+		StockIndexList:  stockIndexList,
+		MutualFundList:  mutualFundList,
+		CommoditiesList: commoditiesList,
+		CurrencyList:    currencyList,
+		EconomicData:    economicData,
+
+		SP500Constituents:           spxConstituents,
+		DowConstituents:             dowConstituents,
+		NasdaqCompositeConstituents: nasdaqCompositeConstituents,
+		Nasdaq100Constituents:       nasdaq100Constituents,
+		FTSE100Constituents:         ftse100Constituents,
+	}
+}
+
+// MirrorEndpoints returns endpoints served from root instead of Quandl and S3.
+// The mirror must use the same paths as the originals: the API lives under
+// root/api/v1/ and the list files under root/ with the path they have below
+// quandl-static-content/, e.g. root/Ticker+CSV%27s/ETFs.csv.
+func MirrorEndpoints(root string) Endpoints {
+	root = strings.TrimRight(root, "/")
+
+	e := DefaultEndpoints()
+	e.APIRoot = root + "/api/v1/datasets/"
+	e.SearchRoot = root + "/api/v1/datasets.json"
+
+	for _, list := range e.lists() {
+		*list = root + "/" + strings.TrimPrefix(*list, staticContentRoot)
+	}
+
+	return e
+}
+
+// lists returns pointers to every list URL so they can be rewritten together.
+func (e *Endpoints) lists() []*string {
+	return []*string{
+		&e.StockList,
+		&e.StockWikiList,
+		&e.StockInfoList,
+		&e.ETFList,
+		&e.StockIndexList,
+		&e.MutualFundList,
+		&e.CommoditiesList,
+		&e.CurrencyList,
+		&e.EconomicData,
+		&e.SP500Constituents,
+		&e.DowConstituents,
+		&e.NasdaqCompositeConstituents,
+		&e.Nasdaq100Constituents,
+		&e.FTSE100Constituents,
+	}
+}
+
+// WithEndpoints sets every URL the client reads from.
+func WithEndpoints(endpoints Endpoints) Option {
+	return func(c *Client) error {
+		if endpoints.APIRoot == "" || endpoints.SearchRoot == "" {
+			return errors.New("quandl: endpoints must include an API and search root")
+		}
+		c.endpoints = endpoints
+		c.endpointsSet = true
+		return nil
+	}
+}
+
+// WithMirror points every endpoint at a local mirror. See MirrorEndpoints.
+func WithMirror(root string) Option {
+	return func(c *Client) error {
+		if _, err := url.Parse(root); err != nil {
+			return err
+		}
+		c.endpoints = MirrorEndpoints(root)
+		c.endpointsSet = true
+		return nil
+	}
+}
+
+// WithProxy sends every request through the HTTP proxy at proxyURL.
+func WithProxy(proxyURL string) Option {
+	return func(c *Client) error {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			return err
+		}
+
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.Proxy = http.ProxyURL(u)
+
+		httpClient := *c.httpClient
+		httpClient.Transport = transport
+		c.httpClient = &httpClient
+		return nil
+	}
+}
@@ -0,0 +1,26 @@
+package quandl
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+)
+
+func ExampleWithMirror() {
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "Code,Name\nWIKI/AAPL,Apple Inc.\nWIKI/MSFT,Microsoft Corporation\n")
+	}))
+	defer mirror.Close()
+
+	c, err := NewClient(WithoutConfig(), WithMirror(mirror.URL))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	identifier, description := c.GetStockList()
+
+	fmt.Printf("%q : %q\n", identifier, description)
+
+	// Output:
+	// ["WIKI/AAPL" "WIKI/MSFT"] : ["Apple Inc." "Microsoft Corporation"]
+}
@@ -0,0 +1,372 @@
+package quandl
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Ratio is a Damodaran financial ratio. Ratios are published per ticker
+// under codes such as DMDRN/MSFT_MKT_CAP; use Code to build them.
+type Ratio string
+
+// The Damodaran ratios, see GetFinancialRatiosList.
+const (
+	RatioFloat                           Ratio = "FLOAT"
+	RatioInsider                         Ratio = "INSIDER"
+	RatioCapex                           Ratio = "CAPEX"
+	RatioNetMargin                       Ratio = "NET_MARG"
+	RatioInvestedCapital                 Ratio = "INV_CAP"
+	RatioPriceToSales                    Ratio = "P_S"
+	RatioReturnOnCapital                 Ratio = "ROC"
+	RatioStockPrice                      Ratio = "STOCK_PX"
+	RatioMarketDebtToEquity              Ratio = "MKT_DE"
+	RatioCorrelation                     Ratio = "CORREL"
+	RatioForwardPE                       Ratio = "PE_FWD"
+	RatioRevenueGrowth                   Ratio = "REV_GRO"
+	RatioPreviousEBIT                    Ratio = "EBIT_1T"
+	RatioDividends                       Ratio = "DIV"
+	RatioForwardEPS                      Ratio = "EPS_FWD"
+	RatioChangeInNonCashWorkingCapital   Ratio = "CHG_NCWC"
+	RatioCashToFirmValue                 Ratio = "CASH_FV"
+	RatioInstitutionalHoldings           Ratio = "INST_HOLD"
+	RatioEffectiveTaxRate                Ratio = "EFF_TAX"
+	RatioCashToAssets                    Ratio = "CASH_ASSETS"
+	RatioFixedToTotalAssets              Ratio = "FIXED_TOT"
+	RatioValueLineBeta                   Ratio = "BETA_VL"
+	RatioBookValueOfAssets               Ratio = "BV_ASSETS"
+	RatioBookValueOfEquity               Ratio = "BV_EQTY"
+	RatioFreeCashFlowToFirm              Ratio = "FCFF"
+	RatioCashToRevenues                  Ratio = "CASH_REV"
+	RatioMarketCap                       Ratio = "MKT_CAP"
+	RatioEffectiveTaxOnIncome            Ratio = "EFF_TAX_INC"
+	RatioEVToSales                       Ratio = "EV_SALES"
+	RatioTotalDebt                       Ratio = "TOT_DEBT"
+	RatioIntangibleToTotalAssets         Ratio = "INTANG_TOT"
+	RatioPEToGrowth                      Ratio = "PE_G"
+	RatioReinvestmentRate                Ratio = "REINV_RATE"
+	RatioBookDebtToCapital               Ratio = "BOOK_DC"
+	RatioExpectedEPSGrowth               Ratio = "EPS_GRO_EXP"
+	RatioEVToEBIT                        Ratio = "EV_EBIT"
+	RatioCurrentPE                       Ratio = "PE_CURR"
+	RatioMarketDebtToCapital             Ratio = "MKT_DC"
+	RatioNonCashWorkingCapitalToRevenues Ratio = "NCWC_REV"
+	RatioTrailing12MonthRevenues         Ratio = "REV_12M"
+	RatioExpectedRevenueGrowth           Ratio = "REV_GRO_EXP"
+	RatioTrailingRevenues                Ratio = "REV_TRAIL"
+	RatioReturnOnEquity                  Ratio = "ROE"
+	RatioEVToEBITDA                      Ratio = "EV_EBITDA"
+	RatioEBITDA                          Ratio = "EBITDA"
+	RatioBeta                            Ratio = "BETA"
+	RatioDepreciation                    Ratio = "DEPREC"
+	RatioEVToTrailingSales               Ratio = "EV_SALESTR"
+	RatioEPSGrowth                       Ratio = "EPS_GRO"
+	RatioPriceToBook                     Ratio = "P_BV"
+	RatioTrailingNetIncome               Ratio = "NET_INC_TRAIL"
+	RatioTrailingPE                      Ratio = "PE_TRAIL"
+	RatioOperatingMargin                 Ratio = "OP_MARG"
+	RatioFirmValue                       Ratio = "FIRM_VAL"
+	RatioStdDev                          Ratio = "STDEV"
+	RatioTradingVolume                   Ratio = "TRAD_VOL"
+	RatioCash                            Ratio = "CASH"
+	RatioDividendYield                   Ratio = "DIV_YLD"
+	RatioRevenues                        Ratio = "REV_LAST"
+	RatioNetIncome                       Ratio = "NET_INC"
+	RatioEVToBook                        Ratio = "EV_BV"
+	RatioReinvestment                    Ratio = "REINV"
+	RatioEBIT                            Ratio = "EBIT"
+	RatioEVToInvestedCapital             Ratio = "EV_CAP"
+	RatioPayout                          Ratio = "PAYOUT"
+	RatioHiLoRisk                        Ratio = "HILO"
+	RatioAll                             Ratio = "ALLFINANCIALRATIOS"
+	RatioSGA                             Ratio = "SGA"
+	RatioEnterpriseValue                 Ratio = "EV"
+	RatioNonCashWorkingCapital           Ratio = "NCWC"
+)
+
+// Unit is what a ratio's values are measured in.
+type Unit int
+
+const (
+	UnitNone     Unit = iota
+	UnitMultiple      // a ratio such as PE or beta
+	UnitFraction      // a percentage as a fraction, e.g. 0.1 for 10%
+	UnitMoney         // US$ millions
+	UnitPerShare      // US$ per share
+	UnitShares        // millions of shares
+)
+
+var unitNames = []string{"", "multiple", "fraction", "US$ millions", "US$ per share", "millions of shares"}
+
+func (u Unit) String() string {
+	if u < 0 || int(u) >= len(unitNames) {
+		return fmt.Sprintf("Unit(%d)", int(u))
+	}
+
+	return unitNames[u]
+}
+
+// ratioInfo describes every ratio, in the order GetFinancialRatiosList
+// has always returned them.
+var ratioInfo = []struct {
+	ratio       Ratio
+	description string
+	unit        Unit
+}{
+	{RatioFloat, "Number of Shares Outstanding", UnitShares},
+	{RatioInsider, "Insider Holdings", UnitFraction},
+	{RatioCapex, "Capital Expenditures", UnitMoney},
+	{RatioNetMargin, "Net Margin", UnitFraction},
+	{RatioInvestedCapital, "Invested Capital", UnitMoney},
+	{RatioPriceToSales, "Price to Sales Ratio", UnitMultiple},
+	{RatioReturnOnCapital, "Return on Capital", UnitFraction},
+	{RatioStockPrice, "Stock Price", UnitPerShare},
+	{RatioMarketDebtToEquity, "Market Debt to Equity Ratio", UnitFraction},
+	{RatioCorrelation, "Correlation with the Market", UnitMultiple},
+	{RatioForwardPE, "Forward PE Ratio", UnitMultiple},
+	{RatioRevenueGrowth, "Previous Year Growth in Revenues", UnitFraction},
+	{RatioPreviousEBIT, "EBIT for Previous Period", UnitMoney},
+	{RatioDividends, "Dividends", UnitMoney},
+	{RatioForwardEPS, "Forward Earnings Per Share", UnitPerShare},
+	{RatioChangeInNonCashWorkingCapital, "Change in Non-Cash Working Capital", UnitMoney},
+	{RatioCashToFirmValue, "Cash as Percentage of Firm Value", UnitFraction},
+	{RatioInstitutionalHoldings, "Institutional Holdings", UnitFraction},
+	{RatioEffectiveTaxRate, "Effective Tax Rate", UnitFraction},
+	{RatioCashToAssets, "Cash as Percentage of Total Assets", UnitFraction},
+	{RatioFixedToTotalAssets, "Ratio of Fixed Assets to Total Assets", UnitFraction},
+	{RatioValueLineBeta, "Value Line Beta", UnitMultiple},
+	{RatioBookValueOfAssets, "Book Value of Assets", UnitMoney},
+	{RatioBookValueOfEquity, "Book Value of Equity", UnitMoney},
+	{RatioFreeCashFlowToFirm, "Free Cash Flow to Firm", UnitMoney},
+	{RatioCashToRevenues, "Cash as Percentage of Revenues", UnitFraction},
+	{RatioMarketCap, "Market Capitalization", UnitMoney},
+	{RatioEffectiveTaxOnIncome, "Effective Tax Rate on Income", UnitFraction},
+	{RatioEVToSales, "EV To Sales Ratio", UnitMultiple},
+	{RatioTotalDebt, "Total Debt", UnitMoney},
+	{RatioIntangibleToTotalAssets, "Ratio of Intangible Assets to Total Assets", UnitFraction},
+	{RatioPEToGrowth, "PE to Growth Ratio", UnitMultiple},
+	{RatioReinvestmentRate, "Reinvestment Rate", UnitFraction},
+	{RatioBookDebtToCapital, "Book Debt to Capital Ratio", UnitFraction},
+	{RatioExpectedEPSGrowth, "Expected Growth in Earnings Per Share", UnitFraction},
+	{RatioEVToEBIT, "EV to EBIT Ratio", UnitMultiple},
+	{RatioCurrentPE, "Current PE Ratio", UnitMultiple},
+	{RatioMarketDebtToCapital, "Market Debt to Capital Ratio", UnitFraction},
+	{RatioNonCashWorkingCapitalToRevenues, "Non-Cash Working Capital as Percentage of Revenues", UnitFraction},
+	{RatioTrailing12MonthRevenues, "Trailing 12-month Revenues", UnitMoney},
+	{RatioExpectedRevenueGrowth, "Expected Growth in Revenues", UnitFraction},
+	{RatioTrailingRevenues, "Trailing Revenues", UnitMoney},
+	{RatioReturnOnEquity, "Return on Equity", UnitFraction},
+	{RatioEVToEBITDA, "EV to EBITDA Ratio", UnitMultiple},
+	{RatioEBITDA, "Earnings Before Interest Taxes Depreciation and Amortization", UnitMoney},
+	{RatioBeta, "3-Year Regression Beta", UnitMultiple},
+	{RatioDepreciation, "Depreciation", UnitMoney},
+	{RatioEVToTrailingSales, "EV to Trailing Sales Ratio", UnitMultiple},
+	{RatioEPSGrowth, "Growth in Earnings Per Share", UnitFraction},
+	{RatioPriceToBook, "Price to Book Value Ratio", UnitMultiple},
+	{RatioTrailingNetIncome, "Trailing Net Income", UnitMoney},
+	{RatioTrailingPE, "Trailing PE Ratio", UnitMultiple},
+	{RatioOperatingMargin, "Pre-Tax Operating Margin", UnitFraction},
+	{RatioFirmValue, "Firm Value", UnitMoney},
+	{RatioStdDev, "3-year Standard Deviation of Stock Price", UnitFraction},
+	{RatioTradingVolume, "Trading Volume", UnitShares},
+	{RatioCash, "Cash", UnitMoney},
+	{RatioDividendYield, "Dividend Yield", UnitFraction},
+	{RatioRevenues, "Revenues", UnitMoney},
+	{RatioNetIncome, "Net Income", UnitMoney},
+	{RatioEVToBook, "EV to Book Value Ratio", UnitMultiple},
+	{RatioReinvestment, "Reinvestment Amount", UnitMoney},
+	{RatioEBIT, "Earnings Before Interest and Taxes", UnitMoney},
+	{RatioEVToInvestedCapital, "EV to Invested Capital Ratio", UnitMultiple},
+	{RatioPayout, "Payout Ratio", UnitFraction},
+	{RatioHiLoRisk, "Hi-Lo Risk", UnitMultiple},
+	{RatioAll, "All Financial Ratios", UnitNone},
+	{RatioSGA, "Sales General and Administration Expenses", UnitMoney},
+	{RatioEnterpriseValue, "Enterprise Value", UnitMoney},
+	{RatioNonCashWorkingCapital, "Non-Cash Working Capital", UnitMoney},
+}
+
+// Ratios returns every Damodaran ratio that is a single series, which is all
+// of them but RatioAll.
+func Ratios() []Ratio {
+	ratios := make([]Ratio, 0, len(ratioInfo))
+	for _, info := range ratioInfo {
+		if info.ratio != RatioAll {
+			ratios = append(ratios, info.ratio)
+		}
+	}
+
+	return ratios
+}
+
+// Description returns the name of the ratio, e.g. "Current PE Ratio".
+func (r Ratio) Description() string {
+	for _, info := range ratioInfo {
+		if info.ratio == r {
+			return info.description
+		}
+	}
+
+	return ""
+}
+
+// Unit returns what the ratio is measured in.
+func (r Ratio) Unit() Unit {
+	for _, info := range ratioInfo {
+		if info.ratio == r {
+			return info.unit
+		}
+	}
+
+	return UnitNone
+}
+
+// Code returns the Quandl code of the ratio for ticker, e.g.
+// DMDRN/BRK_B_MKT_CAP for BRK.B. Tickers are upper cased and dots and dashes
+// become underscores, as in the Damodaran codes.
+func (r Ratio) Code(ticker string) string {
+	ticker = strings.NewReplacer(".", "_", "-", "_").Replace(strings.ToUpper(strings.TrimSpace(ticker)))
+
+	return "DMDRN/" + ticker + "_" + string(r)
+}
+
+// fundamentalsWorkers is how many ratio series GetFundamentals fetches at
+// once. The client's rate limit still applies.
+const fundamentalsWorkers = 8
+
+// panelKey identifies one series in a Panel.
+type panelKey struct {
+	ticker string
+	ratio  Ratio
+}
+
+// Panel is a ticker by ratio by date table of fundamentals.
+type Panel struct {
+	Tickers []string
+	Ratios  []Ratio
+	Dates   []string // every date with a value, oldest first
+
+	series map[panelKey]Series
+}
+
+// NewPanel creates an empty panel for tickers and ratios.
+func NewPanel(tickers []string, ratios []Ratio) *Panel {
+	return &Panel{
+		Tickers: tickers,
+		Ratios:  ratios,
+		series:  make(map[panelKey]Series),
+	}
+}
+
+// Set stores the series for ticker and ratio, replacing any already there.
+// Dates may be in any order.
+func (p *Panel) Set(ticker string, ratio Ratio, dates []string, values []float64) {
+	s := Series{
+		Dates:  append([]string(nil), dates...),
+		Values: append([]float64(nil), values...),
+	}
+	sort.Stable(seriesByDate(s))
+	p.series[panelKey{strings.ToUpper(ticker), ratio}] = s
+
+	seen := make(map[string]bool, len(p.Dates))
+	for _, date := range p.Dates {
+		seen[date] = true
+	}
+	for _, date := range s.Dates {
+		if !seen[date] {
+			seen[date] = true
+			p.Dates = append(p.Dates, date)
+		}
+	}
+	sort.Strings(p.Dates)
+}
+
+// Series returns the dates and values of ratio for ticker, oldest first.
+func (p *Panel) Series(ticker string, ratio Ratio) ([]string, []float64) {
+	s := p.series[panelKey{strings.ToUpper(ticker), ratio}]
+
+	return s.Dates, s.Values
+}
+
+// Value returns ratio for ticker on date, if there is a value for that date.
+func (p *Panel) Value(ticker string, ratio Ratio, date string) (float64, bool) {
+	return p.series[panelKey{strings.ToUpper(ticker), ratio}].Value(date)
+}
+
+// Latest returns the most recent value of ratio for ticker and its date.
+func (p *Panel) Latest(ticker string, ratio Ratio) (string, float64, bool) {
+	s := p.series[panelKey{strings.ToUpper(ticker), ratio}]
+	if s.Len() == 0 {
+		return "", 0, false
+	}
+
+	return s.Dates[s.Len()-1], s.Values[s.Len()-1], true
+}
+
+// GetFundamentals fetches the full history of every ratio for every ticker
+// into a panel
+func GetFundamentals(tickers []string, ratios []Ratio) (*Panel, error) {
+	return defaultClient().GetFundamentals(tickers, ratios)
+}
+
+// GetFundamentals fetches the full history of every ratio for every ticker
+// into a panel. Series are fetched concurrently. Series that cannot be
+// fetched are left out of the panel and their errors joined in the error
+// returned alongside it. Missing values are left out too, so the panel has
+// no observation for them rather than a zero.
+func (c *Client) GetFundamentals(tickers []string, ratios []Ratio) (*Panel, error) {
+	panel := NewPanel(tickers, ratios)
+
+	keys := make(chan panelKey)
+	var (
+		mu   sync.Mutex
+		errs []error
+		wg   sync.WaitGroup
+	)
+
+	for i := 0; i < fundamentalsWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range keys {
+				dates, values, err := c.getRatio(key.ticker, key.ratio)
+
+				mu.Lock()
+				if err != nil {
+					errs = append(errs, err)
+				} else {
+					panel.Set(key.ticker, key.ratio, dates, values)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, ticker := range tickers {
+		for _, ratio := range ratios {
+			keys <- panelKey{ticker, ratio}
+		}
+	}
+	close(keys)
+	wg.Wait()
+
+	return panel, errors.Join(errs...)
+}
+
+// getRatio fetches the history of one ratio for one ticker.
+func (c *Client) getRatio(ticker string, ratio Ratio) ([]string, []float64, error) {
+	code := ratio.Code(ticker)
+
+	q, err := c.GetAllHistory(code)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dates, values, err := q.parseTimeSeries(q.getLikelyDataColumnName(), true)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%v (%s)", err, code)
+	}
+
+	return dates, values, nil
+}
@@ -0,0 +1,68 @@
+package quandl
+
+import "fmt"
+
+// dmdrnFiles are Damodaran datasets served by newMirror.
+var dmdrnFiles = map[string]string{
+	"AAPL_PE_CURR.json": `{"code":"AAPL_PE_CURR","column_names":["Date","Value"],"data":[["2015-12-31",13.2],["2014-12-31",16.1]]}`,
+	"AAPL_ROE.json":     `{"code":"AAPL_ROE","column_names":["Date","Value"],"data":[["2016-12-31",null],["2015-12-31",0.46],["2014-12-31",0.35]]}`,
+	"XOM_PE_CURR.json":  `{"code":"XOM_PE_CURR","column_names":["Date","Value"],"data":[["2015-12-31",19.8]]}`,
+	"XOM_ROE.json":      `{"code":"XOM_ROE","column_names":["Date","Value"],"data":[["2015-12-31",0.09]]}`,
+
+	// A loss making company, whose PE is missing or negative
+	"LOSS_PE_CURR.json": `{"code":"LOSS_PE_CURR","column_names":["Date","Value"],"data":[["2015-12-31",null]]}`,
+	"LOSS_ROE.json":     `{"code":"LOSS_ROE","column_names":["Date","Value"],"data":[["2015-12-31",0.12]]}`,
+	"NEG_PE_CURR.json":  `{"code":"NEG_PE_CURR","column_names":["Date","Value"],"data":[["2015-12-31",-50]]}`,
+	"NEG_ROE.json":      `{"code":"NEG_ROE","column_names":["Date","Value"],"data":[["2015-12-31",0.12]]}`,
+}
+
+func ExampleGetFundamentals() {
+	mirror := newMirror(dmdrnFiles)
+	defer mirror.Close()
+
+	c, err := NewClient(WithoutConfig(), WithMirror(mirror.URL))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	panel, err := c.GetFundamentals([]string{"AAPL", "XOM"}, []Ratio{RatioCurrentPE, RatioReturnOnEquity})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(panel.Dates)
+	for _, ticker := range panel.Tickers {
+		for _, ratio := range panel.Ratios {
+			date, value, _ := panel.Latest(ticker, ratio)
+			fmt.Printf("%s %s %s %v (%s)\n", ticker, ratio, date, value, ratio.Unit())
+		}
+	}
+
+	value, ok := panel.Value("AAPL", RatioCurrentPE, "2014-12-31")
+	fmt.Println(value, ok)
+
+	// A missing value is left out rather than read as 0
+	value, ok = panel.Value("AAPL", RatioReturnOnEquity, "2016-12-31")
+	fmt.Println(value, ok)
+
+	// Output:
+	// [2014-12-31 2015-12-31]
+	// AAPL PE_CURR 2015-12-31 13.2 (multiple)
+	// AAPL ROE 2015-12-31 0.46 (fraction)
+	// XOM PE_CURR 2015-12-31 19.8 (multiple)
+	// XOM ROE 2015-12-31 0.09 (fraction)
+	// 16.1 true
+	// 0 false
+}
+
+func ExampleRatio_Code() {
+	fmt.Println(RatioMarketCap.Code("msft"))
+	fmt.Println(RatioMarketCap.Code("BRK.B"))
+	fmt.Println(RatioMarketCap.Description(), "in", RatioMarketCap.Unit())
+
+	// Output:
+	// DMDRN/MSFT_MKT_CAP
+	// DMDRN/BRK_B_MKT_CAP
+	// Market Capitalization in US$ millions
+}
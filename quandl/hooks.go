@@ -0,0 +1,95 @@
+package quandl
+
+import (
+	"errors"
+	"time"
+)
+
+// Request describes a request made by a Client, as passed to a Hook.
+type Request struct {
+	Identifier string    // dataset code or search query, empty for lists
+	URL        string    // with credentials redacted
+	Attempt    int       // starts at 1 and goes up with each retry
+	Start      time.Time // when this attempt started
+}
+
+// Response describes the outcome of a request, as passed to a Hook.
+type Response struct {
+	StatusCode int // 0 if no response was received
+	Bytes      int
+	Latency    time.Duration
+	Err        error
+}
+
+// Hook is called by a Client around every request. Hooks are called
+// synchronously so they should return quickly.
+type Hook interface {
+	// BeforeRequest is called before each attempt is sent.
+	BeforeRequest(req Request)
+	// AfterResponse is called after each attempt, successful or not.
+	AfterResponse(req Request, resp Response)
+	// OnRetry is called before waiting delay to retry a failed attempt.
+	OnRetry(req Request, err error, delay time.Duration)
+	// OnCacheHit is called instead of the others when the response is cached.
+	OnCacheHit(req Request)
+}
+
+// NopHook implements Hook with methods that do nothing. Embed it in a type
+// to implement only the methods you need.
+type NopHook struct{}
+
+func (NopHook) BeforeRequest(req Request)                           {}
+func (NopHook) AfterResponse(req Request, resp Response)            {}
+func (NopHook) OnRetry(req Request, err error, delay time.Duration) {}
+func (NopHook) OnCacheHit(req Request)                              {}
+
+// WithHooks adds hooks that are called around every request, in order.
+func WithHooks(hooks ...Hook) Option {
+	return func(c *Client) error {
+		for _, hook := range hooks {
+			if hook == nil {
+				return errors.New("quandl: nil hook")
+			}
+		}
+		c.hooks = append(c.hooks, hooks...)
+		return nil
+	}
+}
+
+// WithRetries retries failed requests up to max times. Connection errors,
+// 429 Too Many Requests and 5xx responses are retried, waiting backoff before
+// the first retry and doubling the wait each time after that.
+func WithRetries(max int, backoff time.Duration) Option {
+	return func(c *Client) error {
+		if max < 0 || backoff < 0 {
+			return errors.New("quandl: retries and backoff must not be negative")
+		}
+		c.retries = max
+		c.backoff = backoff
+		return nil
+	}
+}
+
+func (c *Client) beforeRequest(req Request) {
+	for _, hook := range c.hooks {
+		hook.BeforeRequest(req)
+	}
+}
+
+func (c *Client) afterResponse(req Request, resp Response) {
+	for _, hook := range c.hooks {
+		hook.AfterResponse(req, resp)
+	}
+}
+
+func (c *Client) onRetry(req Request, err error, delay time.Duration) {
+	for _, hook := range c.hooks {
+		hook.OnRetry(req, err, delay)
+	}
+}
+
+func (c *Client) onCacheHit(req Request) {
+	for _, hook := range c.hooks {
+		hook.OnCacheHit(req)
+	}
+}
@@ -0,0 +1,215 @@
+package quandl
+
+import (
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// listSpec is how one of the list functions that return parallel code and
+// description slices reads its list.
+type listSpec struct {
+	mapping Mapping
+	extract func([]Row) ([]string, []string)
+}
+
+func extractFields(field1 string, field2 string) func([]Row) ([]string, []string) {
+	return func(list []Row) ([]string, []string) {
+		return fields(list, field1, field2)
+	}
+}
+
+// extractPrefixed prepends prefix to the first field, e.g. WIKI/ to a ticker.
+func extractPrefixed(prefix string, field1 string, field2 string) func([]Row) ([]string, []string) {
+	return func(list []Row) ([]string, []string) {
+		identifier, description := fields(list, field1, field2)
+
+		return prependList(prefix, identifier), description
+	}
+}
+
+// extractCurrencies prepends the source column to each code.
+func extractCurrencies(list []Row) ([]string, []string) {
+	identifier, description := fields(list, FieldCode, FieldName)
+	source, _ := fields(list, FieldSource, FieldName)
+
+	return prependSources(source, identifier), description
+}
+
+var (
+	stockListSpec          = listSpec{wikiListMapping, extractFields(FieldCode, FieldName)}
+	stockTickerListSpec    = listSpec{stockListMapping, extractFields(FieldCode, FieldTicker)}
+	etfListSpec            = listSpec{codeListMapping, extractFields(FieldCode, FieldName)}
+	etfTickerListSpec      = listSpec{codeListMapping, extractFields(FieldCode, FieldTicker)}
+	stockIndexListSpec     = listSpec{codeListMapping, extractFields(FieldCode, FieldName)}
+	commoditiesListSpec    = listSpec{commoditiesListMapping, extractFields(FieldCode, FieldName)}
+	currencyListSpec       = listSpec{currencyListMapping, extractCurrencies}
+	mutualFundListSpec     = listSpec{codeListMapping, extractFields(FieldCode, FieldName)}
+	stockInfoListSpec      = listSpec{stockInfoListMapping, extractPrefixed("WIKI/", FieldTicker, FieldName)}
+	economicDataListSpec   = listSpec{economicListMapping, extractPrefixed("FRED/", FieldCode, FieldName)}
+	constituentListSpec    = listSpec{constituentListMapping, extractPrefixed("WIKI/", FieldTicker, FieldName)}
+	ftse100ListSpec        = listSpec{ftse100ListMapping, extractFields(FieldCode, FieldName)}
+	sp500SectorMappingSpec = listSpec{constituentListMapping, extractPrefixed("WIKI/", FieldTicker, FieldSector)}
+)
+
+// getList loads the list at url for the functions that do not return errors.
+func (c *Client) getList(url string, spec listSpec) ([]string, []string) {
+	return spec.extract(c.loadRows(url, spec.mapping))
+}
+
+// parseList reads a list from r the same way getList loads it from a URL.
+func parseList(r io.Reader, spec listSpec) ([]string, []string, error) {
+	list, err := ParseList(r, spec.mapping)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	identifier, description := spec.extract(list)
+
+	return identifier, description, nil
+}
+
+// ParseStockList reads a list in the format used by GetStockList
+func ParseStockList(r io.Reader) ([]string, []string, error) {
+	return parseList(r, stockListSpec)
+}
+
+// ParseStockTickerList reads a list in the format used by GetStockTickerList
+func ParseStockTickerList(r io.Reader) ([]string, []string, error) {
+	return parseList(r, stockTickerListSpec)
+}
+
+// ParseETFList reads a list in the format used by GetETFList
+func ParseETFList(r io.Reader) ([]string, []string, error) {
+	return parseList(r, etfListSpec)
+}
+
+// ParseETFTickerList reads a list in the format used by GetETFTickerList
+func ParseETFTickerList(r io.Reader) ([]string, []string, error) {
+	return parseList(r, etfTickerListSpec)
+}
+
+// ParseStockIndexList reads a list in the format used by GetStockIndexList
+func ParseStockIndexList(r io.Reader) ([]string, []string, error) {
+	return parseList(r, stockIndexListSpec)
+}
+
+// ParseCommoditiesList reads a list in the format used by GetCommoditiesList
+func ParseCommoditiesList(r io.Reader) ([]string, []string, error) {
+	return parseList(r, commoditiesListSpec)
+}
+
+// ParseCurrencyList reads a list in the format used by GetCurrencyList
+func ParseCurrencyList(r io.Reader) ([]string, []string, error) {
+	return parseList(r, currencyListSpec)
+}
+
+// ParseMutualFundList reads a list in the format used by GetMutualFundList
+func ParseMutualFundList(r io.Reader) ([]string, []string, error) {
+	return parseList(r, mutualFundListSpec)
+}
+
+// ParseStockInfoList reads a list in the format used by GetStockInfoList
+func ParseStockInfoList(r io.Reader) ([]string, []string, error) {
+	return parseList(r, stockInfoListSpec)
+}
+
+// ParseEconomicDataList reads a list in the format used by GetEconomicDataList
+func ParseEconomicDataList(r io.Reader) ([]string, []string, error) {
+	return parseList(r, economicDataListSpec)
+}
+
+// ParseSP500Constituents reads a list in the format used by GetSP500Constituents
+func ParseSP500Constituents(r io.Reader) ([]string, []string, error) {
+	return parseList(r, constituentListSpec)
+}
+
+// ParseDowConstituents reads a list in the format used by GetDowConstituents
+func ParseDowConstituents(r io.Reader) ([]string, []string, error) {
+	return parseList(r, constituentListSpec)
+}
+
+// ParseNasdaqCompositeConstituents reads a list in the format used by
+// GetNasdaqCompositeConstituents
+func ParseNasdaqCompositeConstituents(r io.Reader) ([]string, []string, error) {
+	return parseList(r, constituentListSpec)
+}
+
+// ParseNasdaq100Constituents reads a list in the format used by
+// GetNasdaq100Constituents
+func ParseNasdaq100Constituents(r io.Reader) ([]string, []string, error) {
+	return parseList(r, constituentListSpec)
+}
+
+// ParseFTSE100Constituents reads a list in the format used by
+// GetFTSE100Constituents
+func ParseFTSE100Constituents(r io.Reader) ([]string, []string, error) {
+	return parseList(r, ftse100ListSpec)
+}
+
+// ParseSP500SectorMappings reads a list in the format used by
+// GetSP500SectorMappings
+func ParseSP500SectorMappings(r io.Reader) ([]string, []string, error) {
+	return parseList(r, sp500SectorMappingSpec)
+}
+
+// ParseSecurityMaster reads a list in the format used by GetSecurityMaster
+func ParseSecurityMaster(r io.Reader) (SecurityMaster, error) {
+	list, err := ParseList(r, stockListMapping)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseSecurityMaster(list), nil
+}
+
+// WithListDir reads every ticker list from files in dir instead of S3, so
+// vetted snapshots can be kept with your code. Each file has the name of the
+// list it replaces, e.g. dir/SP500.csv or dir/WIKI_tickers.csv. Only the
+// lists are affected: the API and search roots still come from the other
+// options or the profile. The list dir takes precedence over the list URLs
+// of WithMirror, WithEndpoints and the profile, whatever the order.
+func WithListDir(dir string) Option {
+	return func(c *Client) error {
+		if _, err := os.Stat(dir); err != nil {
+			return err
+		}
+
+		c.listDir = dir
+		return nil
+	}
+}
+
+// useListDir points every list endpoint at its file in the list dir.
+func (c *Client) useListDir() {
+	for _, list := range c.endpoints.lists() {
+		*list = filepath.Join(c.listDir, listFileName(*list))
+	}
+}
+
+// listFileName returns the file name at the end of a list URL, with any
+// escaping removed.
+func listFileName(rawURL string) string {
+	name := path.Base(rawURL)
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		name = unescaped
+	}
+
+	return name
+}
+
+// localPath returns the file a list URL refers to, if it is a file:// URL or
+// a plain path rather than a network address.
+func localPath(rawURL string) (string, bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil || len(u.Scheme) > 1 && u.Scheme != "file" {
+		return "", false
+	}
+	if u.Scheme == "file" {
+		return filepath.FromSlash(u.Path), true
+	}
+
+	return rawURL, true
+}
@@ -0,0 +1,55 @@
+package quandl
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const sp500Snapshot = "Ticker,Name,Sector\nAAPL,Apple Inc.,Information Technology\nXOM,Exxon Mobil Corp.,Energy\n"
+
+func ExampleParseSP500Constituents() {
+	identifier, description, err := ParseSP500Constituents(strings.NewReader(sp500Snapshot))
+
+	fmt.Printf("%q : %q %v\n", identifier, description, err)
+
+	// Output:
+	// ["WIKI/AAPL" "WIKI/XOM"] : ["Apple Inc." "Exxon Mobil Corp."] <nil>
+}
+
+func ExampleWithListDir() {
+	dir, _ := os.MkdirTemp("", "lists")
+	defer os.RemoveAll(dir)
+	os.WriteFile(filepath.Join(dir, "SP500.csv"), []byte(sp500Snapshot), 0600)
+
+	c, err := NewClient(WithoutConfig(), WithListDir(dir))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	identifier, sector := c.GetSP500SectorMappings()
+
+	fmt.Printf("%q : %q\n", identifier, sector)
+
+	// The list dir only replaces the lists, so the API still goes to the
+	// profile's mirror, and it wins over the lists of WithMirror
+	config := filepath.Join(dir, "config")
+	os.WriteFile(config, []byte("[default]\nbase_url = https://quandl-mirror.internal\n"), 0600)
+
+	c, err = NewClient(WithAuthToken("my-secret-token"), WithConfigFile(config), WithProfile(DefaultProfile), WithListDir(dir))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(c)
+
+	c, _ = NewClient(WithoutConfig(), WithListDir(dir), WithMirror("http://localhost:0"))
+	identifier, _ = c.GetSP500SectorMappings()
+	fmt.Printf("%q\n", identifier)
+
+	// Output:
+	// ["WIKI/AAPL" "WIKI/XOM"] : ["Information Technology" "Energy"]
+	// quandl.Client{apiRoot: https://quandl-mirror.internal/api/v1/datasets/, authToken: REDACTED}
+	// ["WIKI/AAPL" "WIKI/XOM"]
+}
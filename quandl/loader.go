@@ -0,0 +1,146 @@
+package quandl
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// delimiters are the separators a list may use, in order of preference when
+// the header row has as many of one as another.
+var delimiters = []rune{',', '|', '\t'}
+
+// sniffLength is how much of a list is examined to find its delimiter.
+const sniffLength = 4096
+
+// ListReader reads records one at a time from a delimited list. It detects
+// the delimiter from the first line, accepts CR, LF and CRLF line endings,
+// skips a UTF-8 byte order mark and allows rows with differing numbers of
+// fields.
+type ListReader struct {
+	csv *csv.Reader
+}
+
+// NewListReader returns a ListReader reading from r.
+func NewListReader(r io.Reader) *ListReader {
+	br := bufio.NewReaderSize(r, sniffLength)
+
+	if bom, err := br.Peek(3); err == nil && bytes.Equal(bom, []byte("\xef\xbb\xbf")) {
+		br.Discard(3)
+	}
+
+	head, _ := br.Peek(sniffLength)
+
+	reader := csv.NewReader(&lineEndingReader{r: br})
+	reader.Comma = sniffDelimiter(head)
+	reader.FieldsPerRecord = -1
+
+	return &ListReader{csv: reader}
+}
+
+// Read returns the next record, or io.EOF at the end of the list. Blank
+// lines are skipped. Parse errors are *csv.ParseError values giving the line
+// and column of the problem.
+func (l *ListReader) Read() ([]string, error) {
+	return l.csv.Read()
+}
+
+// ReadAll returns every remaining record.
+func (l *ListReader) ReadAll() ([][]string, error) {
+	var records [][]string
+
+	for {
+		record, err := l.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return records, err
+		}
+
+		records = append(records, record)
+	}
+}
+
+// openList starts loading the list at url, to be read as it arrives.
+func (c *Client) openList(url string) (io.ReadCloser, error) {
+	return c.open("", url, false)
+}
+
+// loadList loads a delimited list, parsing it as it arrives, and returns
+// any read or parse error.
+func (c *Client) loadList(url string) ([][]string, error) {
+	body, err := c.openList(url)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	records, err := NewListReader(body).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("quandl: %s: %v", c.redact(url), err)
+	}
+
+	return records, nil
+}
+
+// ReadList reads every record from a delimited list, see ListReader.
+func ReadList(r io.Reader) ([][]string, error) {
+	return NewListReader(r).ReadAll()
+}
+
+// sniffDelimiter picks the delimiter that appears most often, outside quotes,
+// in the first line of head.
+func sniffDelimiter(head []byte) rune {
+	counts := make(map[rune]int)
+	quoted := false
+
+	for _, b := range head {
+		if b == '"' {
+			quoted = !quoted
+		} else if !quoted && (b == '\n' || b == '\r') {
+			break
+		} else if !quoted {
+			counts[rune(b)]++
+		}
+	}
+
+	best := delimiters[0]
+	for _, d := range delimiters[1:] {
+		if counts[d] > counts[best] {
+			best = d
+		}
+	}
+
+	return best
+}
+
+// lineEndingReader turns lone \r line endings, as written by old Mac
+// software, into \n so that encoding/csv sees separate lines. \r\n is left
+// alone since encoding/csv already handles it.
+type lineEndingReader struct {
+	r *bufio.Reader
+}
+
+func (l *lineEndingReader) Read(b []byte) (int, error) {
+	n, err := l.r.Read(b)
+
+	for i := 0; i < n; i++ {
+		if b[i] != '\r' {
+			continue
+		}
+
+		if i+1 < n {
+			if b[i+1] != '\n' {
+				b[i] = '\n'
+			}
+		} else if next, perr := l.r.Peek(1); perr != nil || next[0] != '\n' {
+			// The \r ends this chunk, so look ahead to the next one
+			b[i] = '\n'
+		}
+	}
+
+	return n, err
+}
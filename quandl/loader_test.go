@@ -0,0 +1,48 @@
+package quandl
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+func ExampleReadList() {
+	lists := []string{
+		"Ticker,Name\r\nAAPL,Apple Inc.\r\n",
+		"\xef\xbb\xbfCode|Description\rFRED/GDP|Gross Domestic Product\rFRED/UNRATE|Civilian Unemployment Rate|extra\r",
+		"Ticker\tName\nSPY\t\"SPDR S&P 500, ETF\"\n",
+		"Ticker,Name\nAAPL,Apple Inc.\nMSFT,\"Microsoft\" Corp\n",
+	}
+
+	for _, list := range lists {
+		records, err := ReadList(strings.NewReader(list))
+		fmt.Printf("%q %v\n", records, err)
+	}
+
+	// Output:
+	// [["Ticker" "Name"] ["AAPL" "Apple Inc."]] <nil>
+	// [["Code" "Description"] ["FRED/GDP" "Gross Domestic Product"] ["FRED/UNRATE" "Civilian Unemployment Rate" "extra"]] <nil>
+	// [["Ticker" "Name"] ["SPY" "SPDR S&P 500, ETF"]] <nil>
+	// [["Ticker" "Name"] ["AAPL" "Apple Inc."]] parse error on line 3, column 16: extraneous or missing " in quoted-field
+}
+
+func ExampleListReader() {
+	r := NewListReader(strings.NewReader("Ticker|Name\nAAPL|Apple Inc.\n\nMSFT|Microsoft Corp\n"))
+
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Printf("%q\n", record)
+	}
+
+	// Output:
+	// ["Ticker" "Name"]
+	// ["AAPL" "Apple Inc."]
+	// ["MSFT" "Microsoft Corp"]
+}
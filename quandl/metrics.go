@@ -0,0 +1,169 @@
+package quandl
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultBuckets are the request latency histogram buckets, in seconds, used
+// when NewMetrics is given none.
+var DefaultBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// Metrics is a Hook that counts requests, retries and cache hits and records
+// request latency. It serves them in the Prometheus text exposition format,
+// so it can be scraped without depending on the Prometheus client library:
+//
+//	metrics := quandl.NewMetrics()
+//	c, _ := quandl.NewClient(quandl.WithHooks(metrics))
+//	http.Handle("/metrics", metrics)
+type Metrics struct {
+	mu        sync.Mutex
+	requests  map[string]uint64 // by status code, "error" if none
+	retries   uint64
+	cacheHits uint64
+	buckets   []float64
+	counts    []uint64 // per bucket, not cumulative
+	sum       float64
+	count     uint64
+}
+
+var _ Hook = (*Metrics)(nil)
+
+// NewMetrics creates a Metrics with the given latency buckets in seconds, or
+// DefaultBuckets if none are given.
+func NewMetrics(buckets ...float64) *Metrics {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+
+	return &Metrics{
+		requests: make(map[string]uint64),
+		buckets:  buckets,
+		counts:   make([]uint64, len(buckets)),
+	}
+}
+
+// BeforeRequest does nothing; requests are counted when they complete.
+func (m *Metrics) BeforeRequest(req Request) {}
+
+// AfterResponse counts the request by status code and records its latency.
+func (m *Metrics) AfterResponse(req Request, resp Response) {
+	code := "error"
+	if resp.StatusCode != 0 {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[code]++
+	m.observe(resp.Latency)
+}
+
+// OnRetry counts the retry.
+func (m *Metrics) OnRetry(req Request, err error, delay time.Duration) {
+	m.mu.Lock()
+	m.retries++
+	m.mu.Unlock()
+}
+
+// OnCacheHit counts the cache hit.
+func (m *Metrics) OnCacheHit(req Request) {
+	m.mu.Lock()
+	m.cacheHits++
+	m.mu.Unlock()
+}
+
+func (m *Metrics) observe(latency time.Duration) {
+	seconds := latency.Seconds()
+
+	m.sum += seconds
+	m.count++
+
+	for i, bound := range m.buckets {
+		if seconds <= bound {
+			m.counts[i]++
+			return
+		}
+	}
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WriteTo(w)
+}
+
+// WriteTo writes the metrics in the Prometheus text exposition format.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+
+	fmt.Fprintln(cw, "# HELP quandl_requests_total Requests made to Quandl by status code.")
+	fmt.Fprintln(cw, "# TYPE quandl_requests_total counter")
+	codes := make([]string, 0, len(m.requests))
+	for code := range m.requests {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		fmt.Fprintf(cw, "quandl_requests_total{code=%q} %d\n", code, m.requests[code])
+	}
+
+	fmt.Fprintln(cw, "# HELP quandl_retries_total Requests retried after a failure.")
+	fmt.Fprintln(cw, "# TYPE quandl_retries_total counter")
+	fmt.Fprintf(cw, "quandl_retries_total %d\n", m.retries)
+
+	fmt.Fprintln(cw, "# HELP quandl_cache_hits_total Requests answered from the cache.")
+	fmt.Fprintln(cw, "# TYPE quandl_cache_hits_total counter")
+	fmt.Fprintf(cw, "quandl_cache_hits_total %d\n", m.cacheHits)
+
+	fmt.Fprintln(cw, "# HELP quandl_request_duration_seconds Latency of requests made to Quandl.")
+	fmt.Fprintln(cw, "# TYPE quandl_request_duration_seconds histogram")
+	var cumulative uint64
+	for i, bound := range m.buckets {
+		cumulative += m.counts[i]
+		fmt.Fprintf(cw, "quandl_request_duration_seconds_bucket{le=%q} %d\n",
+			strconv.FormatFloat(bound, 'g', -1, 64), cumulative)
+	}
+	fmt.Fprintf(cw, "quandl_request_duration_seconds_bucket{le=\"+Inf\"} %d\n", m.count)
+	fmt.Fprintf(cw, "quandl_request_duration_seconds_sum %s\n", strconv.FormatFloat(m.sum, 'g', -1, 64))
+	fmt.Fprintf(cw, "quandl_request_duration_seconds_count %d\n", m.count)
+
+	if cw.err == nil {
+		cw.err = cw.w.Flush()
+	}
+
+	return cw.n, cw.err
+}
+
+// countingWriter remembers the bytes written and the first error so that
+// WriteTo can use fmt.Fprintf without checking every call.
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	if cw.err != nil {
+		return 0, cw.err
+	}
+
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	cw.err = err
+
+	return n, err
+}
@@ -0,0 +1,45 @@
+package quandl
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+func ExampleMetrics() {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls++; calls == 1 {
+			http.Error(w, "try again", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"code":"AAPL","column_names":["Date","Close"],"data":[["2013-01-04",527]]}`)
+	}))
+	defer server.Close()
+
+	metrics := NewMetrics()
+	c, err := NewClient(WithoutConfig(), WithMirror(server.URL), WithRetries(2, 0), WithHooks(metrics))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	c.GetData("WIKI/AAPL", "2013-01-01", "2013-01-05")
+
+	var buf bytes.Buffer
+	metrics.WriteTo(&buf)
+	for _, line := range strings.Split(buf.String(), "\n") {
+		// Skip the comments and the latencies, which vary from run to run
+		if line != "" && !strings.HasPrefix(line, "#") && !strings.Contains(line, "_bucket") && !strings.Contains(line, "_sum") {
+			fmt.Println(line)
+		}
+	}
+
+	// Output:
+	// quandl_requests_total{code="200"} 1
+	// quandl_requests_total{code="503"} 1
+	// quandl_retries_total 1
+	// quandl_cache_hits_total 0
+	// quandl_request_duration_seconds_count 2
+}
@@ -1,20 +1,18 @@
 package quandl
 
 import (
-	"bufio"
-	"encoding/csv"
 	"encoding/json"
 	"fmt"
-	"io"
-	"io/ioutil"
-	"log"
-	"net/http"
+	"math"
+	"os"
+	"sort"
 	"strings"
+	"sync"
 )
 
 const (
-	quandlApiRoot    = "http://www.quandl.com/api/v1/datasets/"
-	quandlSearchRoot = "http://www.quandl.com/api/v1/datasets.json"
+	quandlApiRoot    = "https://www.quandl.com/api/v1/datasets/"
+	quandlSearchRoot = "https://www.quandl.com/api/v1/datasets.json"
 
 	quandlStockList     = "https://s3.amazonaws.com/quandl-static-content/quandl-stock-code-list.csv"
 	quandlStockWikiList = "https://s3.amazonaws.com/quandl-static-content/Ticker+CSV%27s/WIKI_tickers.csv"
@@ -41,7 +39,38 @@ const (
 	format = ".json"
 )
 
-var authToken string
+var (
+	defaultOnce sync.Once
+	std         *Client
+)
+
+// defaultClient returns the client used by the package level functions. It
+// is created on first use from the environment and config file. If the
+// config file cannot be read every request made with it returns the error,
+// rather than quietly running without the profile's mirror, cache and rate
+// limit. SetDefaultClient replaces it.
+func defaultClient() *Client {
+	defaultOnce.Do(func() {
+		if std != nil {
+			return
+		}
+
+		c, err := NewClient()
+		if err != nil {
+			c, _ = NewClient(WithoutConfig(), WithAuthToken(os.Getenv(EnvAPIKey)))
+			c.configErr = err
+		}
+		std = c
+	})
+
+	return std
+}
+
+// SetDefaultClient replaces the client used by the package level functions.
+func SetDefaultClient(c *Client) {
+	defaultOnce.Do(func() {})
+	std = c
+}
 
 type QuandlResponse struct {
 	SourceCode string      `json:"source_code"`
@@ -66,63 +95,12 @@ type TimeSeries struct {
 // SetAuthToken sets the auth token globally so that all subsequent calls that
 // retrieve data from the Quandl API will use the auth token.
 func SetAuthToken(token string) {
-	authToken = token
-}
-
-func assembleQueryURL(query string) string {
-	var url string
-
-	if authToken == "" {
-		fmt.Printf("No auth token set. API calls are limited.\n")
-		url = fmt.Sprintf("%s?query=%s", quandlSearchRoot, query)
-	} else {
-		url = fmt.Sprintf("%s?query=%s&auth_token=%s", quandlSearchRoot, query, authToken)
-	}
-	return url
+	defaultClient().authToken = token
 }
 
-func assembleURLwithDates(identifier string, startDate string, endDate string) string {
-	var url string
-
-	if authToken == "" {
-		fmt.Printf("No auth token set. API calls are limited.\n")
-		url = fmt.Sprintf("%s%s%s?trim_start=%s&trim_end=%s", quandlApiRoot, identifier, format, startDate, endDate)
-	} else {
-		url = fmt.Sprintf("%s%s%s?trim_start=%s&trim_end=%s&auth_token=%s", quandlApiRoot, identifier, format, startDate, endDate, authToken)
-	}
-	return url
-}
-
-func assembleURLwithoutDates(identifier string) string {
-	var url string
-	if authToken == "" {
-		fmt.Printf("No auth token set. API calls are limited.\n")
-		url = fmt.Sprintf("%s%s%s", quandlApiRoot, identifier, format)
-	} else {
-		url = fmt.Sprintf("%s%s%s?auth_token=%s", quandlApiRoot, identifier, format, authToken)
-	}
-	return url
-}
-
-func readBytesFromUrl(url string) ([]byte, error) {
-	resp, err := http.Get(url)
+func (c *Client) getDataFromURL(identifier string, url string) (*QuandlResponse, error) {
+	body, err := c.get(identifier, url, true)
 	if err != nil {
-		log.Fatal(err)
-		return nil, err
-	}
-
-	defer resp.Body.Close()
-	body, err := ioutil.ReadAll(resp.Body)
-
-	return body, err
-}
-
-func getDataFromURL(url string) (*QuandlResponse, error) {
-	//fmt.Printf("%s\n", url)
-
-	body, err := readBytesFromUrl(url)
-	if err != nil {
-		log.Fatal(err)
 		return nil, err
 	}
 
@@ -130,11 +108,11 @@ func getDataFromURL(url string) (*QuandlResponse, error) {
 
 	err = json.Unmarshal(body, &quandlResponse)
 	if err != nil {
-		log.Fatal(err)
+		err = fmt.Errorf("quandl: decoding %s: %v", identifier, err)
+		c.logger.Error("quandl decode failed", "identifier", identifier, "err", err)
 		return nil, err
 	}
 
-	//fmt.Printf("%s\n", quandlResponse)
 	return quandlResponse, nil
 }
 
@@ -142,16 +120,12 @@ func getDataFromURL(url string) (*QuandlResponse, error) {
 // You can optionally set the auth token before running this function so that you
 // can make unlimited API calls instead of being limited to 500/day.
 func GetData(identifier string, startDate string, endDate string) (*QuandlResponse, error) {
-	url := assembleURLwithDates(identifier, startDate, endDate)
-
-	return getDataFromURL(url)
+	return defaultClient().GetData(identifier, startDate, endDate)
 }
 
 // GetAllHistory is similar to GetData except that it does not restrict a date range
 func GetAllHistory(identifier string) (*QuandlResponse, error) {
-	url := assembleURLwithoutDates(identifier)
-
-	return getDataFromURL(url)
+	return defaultClient().GetAllHistory(identifier)
 }
 
 // GetTimeSeriesColumn returns the data from the Quandl response for a particular column.
@@ -186,54 +160,164 @@ func (q *QuandlResponse) GetTimeSeriesDate() []string {
 }
 
 // GetTimeSeries returns a date vector and the value vector for a particular
-// column in the QuandlResponse
+// column in the QuandlResponse. It returns nil vectors if the data cannot be
+// read; use ParseTimeSeries to find out why.
 func (q *QuandlResponse) GetTimeSeries(column string) ([]string, []float64) {
+	dateVector, dataVector, err := q.ParseTimeSeries(column)
+	if err != nil {
+		return nil, nil
+	}
+
+	return dateVector, dataVector
+}
+
+// ParseTimeSeries returns a date vector and the value vector for a particular
+// column in the QuandlResponse, or an error describing the first value that
+// could not be read. Missing values are returned as 0.
+func (q *QuandlResponse) ParseTimeSeries(column string) ([]string, []float64, error) {
+	return q.parseTimeSeries(column, false)
+}
+
+// parseTimeSeries is ParseTimeSeries, leaving out the rows where column is
+// missing if skipMissing is set.
+func (q *QuandlResponse) parseTimeSeries(column string, skipMissing bool) ([]string, []float64, error) {
+	if q.Data == nil {
+		return nil, nil, nil
+	}
+
+	rows, err := q.rows()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dataColumnNum := q.getColumnNum(column)
+	if dataColumnNum == -1 {
+		return nil, nil, fmt.Errorf("quandl: no %q column in %q", column, q.Columns)
+	}
+
+	dateVector := make([]string, 0, len(rows))
+	dataVector := make([]float64, 0, len(rows))
+
+	for _, r := range rows {
+		value, ok, err := r.float(dataColumnNum, column)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !ok && skipMissing {
+			continue
+		}
+
+		dateVector = append(dateVector, r.date)
+		dataVector = append(dataVector, value)
+	}
+
+	return dateVector, dataVector, nil
+}
+
+// row is one row of Data with its date.
+type row struct {
+	num    int // position in Data, for errors
+	date   string
+	values []interface{}
+}
+
+// rows checks that Data is a list of rows, each with a value for every
+// column and a date in the Date column, and returns them in the order given.
+func (q *QuandlResponse) rows() ([]row, error) {
 	if q.Data == nil {
 		return nil, nil
 	}
 
-	dataArray := q.Data.([]interface{})
+	dataArray, ok := q.Data.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("quandl: data is a %T, not a list of rows", q.Data)
+	}
 
-	dateVector := make([]string, 0, len(dataArray))
-	dataVector := make([]float64, 0, len(dataArray))
 	dateColumnNum := q.getColumnNum("Date")
-	dataColumnNum := q.getColumnNum(column)
+	if dateColumnNum == -1 {
+		return nil, fmt.Errorf("quandl: no Date column in %q", q.Columns)
+	}
 
+	rows := make([]row, len(dataArray))
 	for k, v := range dataArray {
-		switch vv := v.(type) {
-		case []interface{}:
-			// Check that 0 is the date
-			switch vv[dateColumnNum].(type) {
-			case string:
-				dateVector = append(dateVector, vv[dateColumnNum].(string))
-			default:
-				fmt.Printf("Problem reading %q as a string.\n", vv[dateColumnNum])
-				return nil, nil
-			}
+		vv, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("quandl: row %d is a %T, not a list of values", k, v)
+		}
+		if len(vv) < len(q.Columns) {
+			return nil, fmt.Errorf("quandl: row %d has %d values, expected %d", k, len(vv), len(q.Columns))
+		}
+
+		date, ok := vv[dateColumnNum].(string)
+		if !ok {
+			return nil, fmt.Errorf("quandl: row %d: cannot read %v as a date", k, vv[dateColumnNum])
+		}
+
+		rows[k] = row{num: k, date: date, values: vv}
+	}
 
-			// Match the right column with the requested column
-			switch vv[dataColumnNum].(type) {
-			case float64:
-				dataVector = append(dataVector, vv[dataColumnNum].(float64))
-			case nil:
-				dataVector = append(dataVector, 0)
-			default:
-				fmt.Printf("Problem reading %q as a float64.\n", vv[dataColumnNum])
-				return nil, nil
+	return rows, nil
+}
+
+// readColumns reads columns from every row in one pass, oldest first, so
+// that their values line up by row: values[j][i] is columns[j] on dates[i].
+// Missing values are NaN.
+func (q *QuandlResponse) readColumns(columns ...string) ([]string, [][]float64, error) {
+	values := make([][]float64, len(columns))
+	if q.Data == nil {
+		return nil, values, nil
+	}
+
+	rows, err := q.rows()
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].date < rows[j].date })
+
+	nums := make([]int, len(columns))
+	for j, column := range columns {
+		if nums[j] = q.getColumnNum(column); nums[j] == -1 {
+			return nil, nil, fmt.Errorf("quandl: no %q column in %q", column, q.Columns)
+		}
+		values[j] = make([]float64, len(rows))
+	}
+
+	dates := make([]string, len(rows))
+	for i, r := range rows {
+		dates[i] = r.date
+		for j, num := range nums {
+			value, ok, err := r.float(num, columns[j])
+			if err != nil {
+				return nil, nil, err
+			}
+			if !ok {
+				value = math.NaN()
 			}
-		default:
-			fmt.Println(k, "is of a type I don't know how to handle")
-			return nil, nil
+			values[j][i] = value
 		}
 	}
 
-	return dateVector, dataVector
+	return dates, values, nil
+}
+
+// float returns the value in column number i, named column for errors, and
+// whether it is present. Missing values are null in the JSON and returned
+// as 0.
+func (r row) float(i int, column string) (float64, bool, error) {
+	switch value := r.values[i].(type) {
+	case float64:
+		return value, true, nil
+	case nil:
+		return 0, false, nil
+	default:
+		return 0, false, fmt.Errorf("quandl: row %d: cannot read %v as a float64 in column %q", r.num, value, column)
+	}
 }
 
 // getLikelyDataColumnName finds the column most likely to be the "data"
 // column. It either uses adjusted close or just takes the last column in the series
 func (q *QuandlResponse) getLikelyDataColumnName() string {
-	adjustedCloseColumn := q.getColumnNum("Adj. Close")
+	adjustedCloseColumn := q.getColumnNum(ColumnAdjClose)
 
 	if len(q.Columns) < 1 {
 		return "N/A"
@@ -260,239 +344,155 @@ func (q *QuandlResponse) getColumnNum(column string) int {
 // as a byte stream. In future releases of this Go (golang) Quandl package
 // this will return a native object instead of the json
 func Search(query string) ([]byte, error) {
-	query = strings.Replace(query, " ", "+", -1)
-
-	url := assembleQueryURL(query)
-
-	body, err := readBytesFromUrl(url)
-
-	return body, err
-}
-
-func loadPipeDelimited(url string) [][]string {
-	resp, err := http.Get(url)
-	if err != nil {
-		log.Fatal(err)
-		return nil
-	}
-
-	defer resp.Body.Close()
-
-	reader := csv.NewReader(resp.Body)
-	reader.Comma = '|'
-
-	records, _ := reader.ReadAll()
-
-	return records
-}
-
-type linefeedConverter struct {
-	r *bufio.Reader
+	return defaultClient().Search(query)
 }
 
-func newLinefeedConverter(r io.Reader) io.Reader {
-	return linefeedConverter{bufio.NewReader(r)}
-}
-
-func (r linefeedConverter) Read(b []byte) (int, error) {
-	n, err := r.r.Read(b)
-	if err != nil {
-		return n, err
-	}
-	b = b[:n]
-	for i := range b {
-		if b[i] == '\r' {
-			var next byte
-			if j := i + 1; j < len(b) {
-				next = b[j]
-			} else {
-				next, err = r.r.ReadByte()
-				if err == nil {
-					r.r.UnreadByte()
-				}
-			}
-			if next != '\n' {
-				b[i] = '\n'
-			}
-			// } else if b[i] == '!' { // ! character confuses cvsReader
-			// 	// fmt.Print("!FOUND!")
-			// 	b[i] = '_'
-		}
-		// fmt.Printf("%c", b[i])
-	}
-	fmt.Println("buffer", string(b), "length", n)
-	return n, err
-}
-
-func loadCSVMac(url string) (records [][]string) {
-	resp, err := http.Get(url)
-	if err != nil {
-		log.Fatal(err)
-		return nil
-	}
-
-	defer resp.Body.Close()
-
-	c, err := ioutil.ReadAll(resp.Body)
+// loadCSV loads a list for the functions that do not return errors. Any
+// error is logged and whatever records were read are returned.
+func (c *Client) loadCSV(url string) [][]string {
+	records, err := c.loadList(url)
 	if err != nil {
-		log.Fatal(err)
-		return nil
+		c.logger.Error("quandl list load failed", "url", c.redact(url), "err", err)
 	}
-	cs := strings.Replace(string(c), "\r", "\n", -1)
-
-	reader := csv.NewReader(strings.NewReader(cs))
-
-	// for i := 0; ; i++ {
-	// 	record, err := reader.Read()
-	// 	if err == io.EOF {
-	// 		break
-	// 	} else if err != nil {
-	// 		fmt.Println("line", i, "error", err)
-	// 		break
-	// 	}
-	// 	records = append(records, record)
-	// }
-	records, _ = reader.ReadAll()
 
 	return records
 }
 
-func loadCSV(url string) [][]string {
-	//file, err := os.Open(fileName)
-	resp, err := http.Get(url)
+// loadRows is LoadList for the functions that do not return errors. Any
+// error, including a missing required column, is logged and nil returned.
+func (c *Client) loadRows(url string, mapping Mapping) []Row {
+	list, err := c.LoadList(url, mapping)
 	if err != nil {
-		log.Fatal(err)
+		c.logger.Error("quandl list load failed", "url", c.redact(url), "err", err)
 		return nil
 	}
 
-	defer resp.Body.Close()
-
-	reader := csv.NewReader(resp.Body)
-
-	// for i := 0; true; i++ {
-	// 	record, err := reader.Read()
-
-	// 	if err == io.EOF {
-	// 		break
-	// 	} else if err != nil {
-	// 		log.Fatal(err)
-	// 		return nil
-	// 	} else {
-	// 		if i <= 1 {
-	// 			fmt.Printf("%q\n", record)
-	// 		}
-	// 	}
-	// }
-
-	records, _ := reader.ReadAll()
-
-	return records
+	return list
 }
 
-func getCsvColumnNumber(csvArray [][]string, column string) int {
-	for i, v := range csvArray[0][:] {
-		if v == column {
-			return i
-		}
-	}
-
-	return -1
-}
-
-// Get various lists
-func extractColumns(csvArray [][]string, column1 int, column2 int, skipHeader bool) ([]string, []string) {
-	identifier := make([]string, 0, len(csvArray))
-	description := make([]string, 0, len(csvArray))
-
-	for i, v := range csvArray[:] {
-		for j, v2 := range v {
-			// If the header is included then we use the first row
-			// otherwise we skip the first row
-			if (!skipHeader && i == 0) || i > 0 {
-				if j == column1 {
-					identifier = append(identifier, v2)
-				} else if j == column2 {
-					description = append(description, v2)
-				}
-			}
+// GetAllSecurityList gets all the security identifiers and descriptions, one
+// per Quandl code. The description is the security's name, or its ticker if
+// no list has a name for it. Use GetAllSecurities for both, and for where
+// each came from.
+func GetAllSecurityList() ([]string, []string) {
+	return defaultClient().GetAllSecurityList()
+}
+
+// GetAllSecurityList gets all the security identifiers and descriptions, one
+// per Quandl code. The description is the security's name, or its ticker if
+// no list has a name for it. Use GetAllSecurities for both, and for where
+// each came from.
+func (c *Client) GetAllSecurityList() ([]string, []string) {
+	/*lists that cannot be loaded are logged and skipped*/
+	catalog, _ := c.assembleCatalog(func(url string, mapping Mapping) ([]Row, error) {
+		return c.loadRows(url, mapping), nil
+	})
+
+	identifier := make([]string, 0, catalog.Len())
+	description := make([]string, 0, catalog.Len())
+
+	for _, s := range catalog.securities {
+		identifier = append(identifier, s.Code)
+		if s.Name != "" {
+			description = append(description, s.Name)
+		} else {
+			description = append(description, s.Ticker)
 		}
 	}
 
 	return identifier, description
 }
 
-// GetAllSecurityList gets all the security identifiers and descriptions
-func GetAllSecurityList() ([]string, []string) {
-
-	identifier, description := GetStockList()
-
-	tempIdentifier, tempDescription := GetStockTickerList()
-	identifier = append(identifier, tempIdentifier...)
-	description = append(description, tempDescription...)
-
-	tempIdentifier, tempDescription = GetETFList()
-	identifier = append(identifier, tempIdentifier...)
-	description = append(description, tempDescription...)
-
-	tempIdentifier, tempDescription = GetETFTickerList()
-	identifier = append(identifier, tempIdentifier...)
-	description = append(description, tempDescription...)
-
-	tempIdentifier, tempDescription = GetStockIndexList()
-	identifier = append(identifier, tempIdentifier...)
-	description = append(description, tempDescription...)
-
-	tempIdentifier, tempDescription = GetCommoditiesList()
-	identifier = append(identifier, tempIdentifier...)
-	description = append(description, tempDescription...)
-
-	tempIdentifier, tempDescription = GetBitcoinList()
-	identifier = append(identifier, tempIdentifier...)
-	description = append(description, tempDescription...)
-
-	return identifier, description
-}
-
 // GetStockList gets all the Quandl stock codes and descriptions
 func GetStockList() ([]string, []string) {
-	list := loadCSV(quandlStockWikiList)
+	return defaultClient().GetStockList()
+}
 
-	return extractColumns(list, 0, 1, true)
+// GetStockList gets all the Quandl stock codes and descriptions
+func (c *Client) GetStockList() ([]string, []string) {
+	return c.getList(c.endpoints.StockWikiList, stockListSpec)
 }
 
 // GetStockTickerList gets all the Quandl codes and tickers
 func GetStockTickerList() ([]string, []string) {
-	list := loadCSV(quandlStockList)
+	return defaultClient().GetStockTickerList()
+}
 
-	return extractColumns(list, 2, 0, true)
+// GetStockTickerList gets all the Quandl codes and tickers
+func (c *Client) GetStockTickerList() ([]string, []string) {
+	return c.getList(c.endpoints.StockList, stockTickerListSpec)
 }
 
 // GetETFList gets all the Quandl codes and ETF descriptions
 func GetETFList() ([]string, []string) {
-	list := loadCSV(etfList)
+	return defaultClient().GetETFList()
+}
 
-	return extractColumns(list, 1, 2, true)
+// GetETFList gets all the Quandl codes and ETF descriptions
+func (c *Client) GetETFList() ([]string, []string) {
+	return c.getList(c.endpoints.ETFList, etfListSpec)
 }
 
 // GetETFTickerList gets all the Quandl codes and ETF tickers
 func GetETFTickerList() ([]string, []string) {
-	list := loadCSV(etfList)
+	return defaultClient().GetETFTickerList()
+}
 
-	return extractColumns(list, 1, 0, true)
+// GetETFTickerList gets all the Quandl codes and ETF tickers
+func (c *Client) GetETFTickerList() ([]string, []string) {
+	return c.getList(c.endpoints.ETFList, etfTickerListSpec)
 }
 
 // GetStockIndexList gets all the Quandl codes and stock index descriptions
 func GetStockIndexList() ([]string, []string) {
-	list := loadCSV(stockIndexList)
+	return defaultClient().GetStockIndexList()
+}
 
-	return extractColumns(list, 1, 2, true)
+// GetStockIndexList gets all the Quandl codes and stock index descriptions
+func (c *Client) GetStockIndexList() ([]string, []string) {
+	return c.getList(c.endpoints.StockIndexList, stockIndexListSpec)
 }
 
 // GetCommoditiesList gets all the Quandl codes and commodity descriptions
 func GetCommoditiesList() ([]string, []string) {
-	list := loadCSV(commoditiesList)
+	return defaultClient().GetCommoditiesList()
+}
+
+// GetCommoditiesList gets all the Quandl codes and commodity descriptions
+func (c *Client) GetCommoditiesList() ([]string, []string) {
+	return c.getList(c.endpoints.CommoditiesList, commoditiesListSpec)
+}
+
+// GetCurrencyList gets all the Quandl codes and currency descriptions
+func GetCurrencyList() ([]string, []string) {
+	return defaultClient().GetCurrencyList()
+}
+
+// GetCurrencyList gets all the Quandl codes and currency descriptions
+func (c *Client) GetCurrencyList() ([]string, []string) {
+	return c.getList(c.endpoints.CurrencyList, currencyListSpec)
+}
+
+// GetMutualFundList gets all the Quandl codes and mutual fund descriptions
+func GetMutualFundList() ([]string, []string) {
+	return defaultClient().GetMutualFundList()
+}
 
-	return extractColumns(list, 1, 0, true)
+// GetMutualFundList gets all the Quandl codes and mutual fund descriptions
+func (c *Client) GetMutualFundList() ([]string, []string) {
+	return c.getList(c.endpoints.MutualFundList, mutualFundListSpec)
+}
+
+// GetStockInfoList gets the Quandl codes and company names from the stock
+// info list, which also has each company's exchange, sector and industry
+func GetStockInfoList() ([]string, []string) {
+	return defaultClient().GetStockInfoList()
+}
+
+// GetStockInfoList gets the Quandl codes and company names from the stock
+// info list, which also has each company's exchange, sector and industry
+func (c *Client) GetStockInfoList() ([]string, []string) {
+	return c.getList(c.endpoints.StockInfoList, stockInfoListSpec)
 }
 
 // GetBitcoinList just returns http://www.quandl.com/api/v1/datasets/BITCOIN/BITSTAMPUSD
@@ -511,152 +511,94 @@ func GetBitcoinList() ([]string, []string) {
 // GetFinancialRatiosList returns the list of Damordoran financial ratios. Currently
 // this list is hard-coded into the file because Quandl does not provide a file from
 // where to read. A caveat about using these is that you need to append the ticker
-// in a particular way to use these ratios
+// in a particular way to use these ratios; Ratio.Code does it for you and
+// GetFundamentals fetches them
 func GetFinancialRatiosList() ([]string, []string) {
-	test := [][]string{{"FLOAT", "Number of Shares Outstanding"},
-		{"INSIDER", "Insider Holdings"},
-		{"CAPEX", "Capital Expenditures"},
-		{"NET_MARG", "Net Margin"},
-		{"INV_CAP", "Invested Capital"},
-		{"P_S", "Price to Sales Ratio"},
-		{"ROC", "Return on Capital"},
-		{"STOCK_PX", "Stock Price"},
-		{"MKT_DE", "Market Debt to Equity Ratio"},
-		{"CORREL", "Correlation with the Market"},
-		{"PE_FWD", "Forward PE Ratio"},
-		{"REV_GRO", "Previous Year Growth in Revenues"},
-		{"EBIT_1T", "EBIT for Previous Period"},
-		{"DIV", "Dividends"},
-		{"EPS_FWD", "Forward Earnings Per Share"},
-		{"CHG_NCWC", "Change in Non-Cash Working Capital"},
-		{"CASH_FV", "Cash as Percentage of Firm Value"},
-		{"INST_HOLD", "Institutional Holdings"},
-		{"EFF_TAX", "Effective Tax Rate"},
-		{"CASH_ASSETS", "Cash as Percentage of Total Assets"},
-		{"FIXED_TOT", "Ratio of Fixed Assets to Total Assets"},
-		{"BETA_VL", "Value Line Beta"},
-		{"BV_ASSETS", "Book Value of Assets"},
-		{"BV_EQTY", "Book Value of Equity"},
-		{"FCFF", "Free Cash Flow to Firm"},
-		{"CASH_REV", "Cash as Percentage of Revenues"},
-		{"MKT_CAP", "Market Capitalization"},
-		{"EFF_TAX_INC", "Effective Tax Rate on Income"},
-		{"EV_SALES", "EV To Sales Ratio"},
-		{"TOT_DEBT", "Total Debt"},
-		{"INTANG_TOT", "Ratio of Intangible Assets to Total Assets"},
-		{"PE_G", "PE to Growth Ratio"},
-		{"REINV_RATE", "Reinvestment Rate"},
-		{"BOOK_DC", "Book Debt to Capital Ratio"},
-		{"EPS_GRO_EXP", "Expected Growth in Earnings Per Share"},
-		{"EV_EBIT", "EV to EBIT Ratio"},
-		{"PE_CURR", "Current PE Ratio"},
-		{"MKT_DC", "Market Debt to Capital Ratio"},
-		{"NCWC_REV", "Non-Cash Working Capital as Percentage of Revenues"},
-		{"REV_12M", "Trailing 12-month Revenues"},
-		{"REV_GRO_EXP", "Expected Growth in Revenues"},
-		{"REV_TRAIL", "Trailing Revenues"},
-		{"ROE", "Return on Equity"},
-		{"EV_EBITDA", "EV to EBITDA Ratio"},
-		{"EBITDA", "Earnings Before Interest Taxes Depreciation and Amortization"},
-		{"BETA", "3-Year Regression Beta"},
-		{"DEPREC", "Depreciation"},
-		{"EV_SALESTR", "EV to Trailing Sales Ratio"},
-		{"EPS_GRO", "Growth in Earnings Per Share"},
-		{"P_BV", "Price to Book Value Ratio"},
-		{"NET_INC_TRAIL", "Trailing Net Income"},
-		{"PE_TRAIL", "Trailing PE Ratio"},
-		{"OP_MARG", "Pre-Tax Operating Margin"},
-		{"FIRM_VAL", "Firm Value"},
-		{"STDEV", "3-year Standard Deviation of Stock Price"},
-		{"TRAD_VOL", "Trading Volume"},
-		{"CASH", "Cash"},
-		{"DIV_YLD", "Dividend Yield"},
-		{"REV_LAST", "Revenues"},
-		{"NET_INC", "Net Income"},
-		{"EV_BV", "EV to Book Value Ratio"},
-		{"REINV", "Reinvestment Amount"},
-		{"EBIT", "Earnings Before Interest and Taxes"},
-		{"EV_CAP", "EV to Invested Capital Ratio"},
-		{"PAYOUT", "Payout Ratio"},
-		{"HILO", "Hi-Lo Risk"},
-		{"ALLFINANCIALRATIOS", "All Financial Ratios"},
-		{"SGA", "Sales General and Administration Expenses"},
-		{"EV", "Enterprise Value"},
-		{"NCWC", "Non-Cash Working Capital"},
+	identifier := make([]string, len(ratioInfo))
+	description := make([]string, len(ratioInfo))
+
+	for i, info := range ratioInfo {
+		identifier[i] = string(info.ratio)
+		description[i] = info.description
 	}
 
-	return extractColumns(test, 0, 1, false)
+	return identifier, description
 }
 
 // Economic data (doesn't pertain to a particular security)
 // GetEconomicDataList
 func GetEconomicDataList() ([]string, []string) {
-	list := loadPipeDelimited(economicData)
-
-	identifier, description := extractColumns(list, 0, 1, true)
+	return defaultClient().GetEconomicDataList()
+}
 
-	return prependList("FRED/", identifier), description
+// Economic data (doesn't pertain to a particular security)
+// GetEconomicDataList
+func (c *Client) GetEconomicDataList() ([]string, []string) {
+	return c.getList(c.endpoints.EconomicData, economicDataListSpec)
 }
 
 // Index membership
 // GetSP500Constituents
 func GetSP500Constituents() ([]string, []string) {
-	//fmt.Printf("%s\n", spxConstituents)
-	list := loadCSVMac(spxConstituents)
-	/*need to prepend col 0 ticker with WIKI*/
-
-	identifier, description := extractColumns(list, 0, 2, true)
+	return defaultClient().GetSP500Constituents()
+}
 
-	return prependList("WIKI/", identifier), description
+// Index membership
+// GetSP500Constituents
+func (c *Client) GetSP500Constituents() ([]string, []string) {
+	return c.getList(c.endpoints.SP500Constituents, constituentListSpec)
 }
 
 // GetDowConstituents
 func GetDowConstituents() ([]string, []string) {
-	list := loadCSV(dowConstituents)
-	/*need to prepend col 0 ticker with WIKI*/
-
-	identifier, description := extractColumns(list, 0, 2, true)
+	return defaultClient().GetDowConstituents()
+}
 
-	return prependList("WIKI/", identifier), description
+// GetDowConstituents
+func (c *Client) GetDowConstituents() ([]string, []string) {
+	return c.getList(c.endpoints.DowConstituents, constituentListSpec)
 }
 
 // GetNasdaqCompositeConstituents
 func GetNasdaqCompositeConstituents() ([]string, []string) {
-	list := loadCSV(nasdaqCompositeConstituents)
-	/*need to prepend col 0 ticker with WIKI*/
-
-	identifier, description := extractColumns(list, 0, 2, true)
+	return defaultClient().GetNasdaqCompositeConstituents()
+}
 
-	return prependList("WIKI/", identifier), description
+// GetNasdaqCompositeConstituents
+func (c *Client) GetNasdaqCompositeConstituents() ([]string, []string) {
+	return c.getList(c.endpoints.NasdaqCompositeConstituents, constituentListSpec)
 }
 
 // GetNasdaq100Constituents
 func GetNasdaq100Constituents() ([]string, []string) {
-	list := loadCSV(nasdaq100Constituents)
-	/*need to prepend col 0 ticker with WIKI*/
-
-	identifier, description := extractColumns(list, 0, 2, true)
+	return defaultClient().GetNasdaq100Constituents()
+}
 
-	return prependList("WIKI/", identifier), description
+// GetNasdaq100Constituents
+func (c *Client) GetNasdaq100Constituents() ([]string, []string) {
+	return c.getList(c.endpoints.Nasdaq100Constituents, constituentListSpec)
 }
 
 // GetFTSE100Constituents
 func GetFTSE100Constituents() ([]string, []string) {
-	list := loadCSV(ftse100Constituents)
-
-	identifier, description := extractColumns(list, 1, 2, true)
+	return defaultClient().GetFTSE100Constituents()
+}
 
-	return identifier, description
+// GetFTSE100Constituents
+func (c *Client) GetFTSE100Constituents() ([]string, []string) {
+	return c.getList(c.endpoints.FTSE100Constituents, ftse100ListSpec)
 }
 
 // Sector mappings
 // GetSP500SectorMappings
 func GetSP500SectorMappings() ([]string, []string) {
-	list := loadCSVMac(spxConstituents)
-
-	identifier, description := extractColumns(list, 0, 3, true)
+	return defaultClient().GetSP500SectorMappings()
+}
 
-	return prependList("WIKI/", identifier), description
+// Sector mappings
+// GetSP500SectorMappings
+func (c *Client) GetSP500SectorMappings() ([]string, []string) {
+	return c.getList(c.endpoints.SP500Constituents, sp500SectorMappingSpec)
 }
 
 func prependList(prefix string, list []string) []string {
@@ -668,3 +610,19 @@ func prependList(prefix string, list []string) []string {
 
 	return newList
 }
+
+// prependSources prefixes each code with its source, e.g. CURRFX and USDEUR
+// become CURRFX/USDEUR. Codes that already have a source are left alone.
+func prependSources(sources []string, codes []string) []string {
+	newList := make([]string, len(codes), len(codes))
+
+	for i, v := range codes {
+		if i < len(sources) && sources[i] != "" && !strings.Contains(v, "/") {
+			newList[i] = fmt.Sprintf("%s/%s", sources[i], v)
+		} else {
+			newList[i] = v
+		}
+	}
+
+	return newList
+}
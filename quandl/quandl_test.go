@@ -70,7 +70,7 @@ func Example() {
 }
 
 func ExampleLoadCSV() {
-	output := loadCSV(quandlStockList)
+	output := defaultClient().loadCSV(quandlStockList)
 
 	fmt.Printf("%q\n", output[0:2][:])
 
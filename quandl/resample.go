@@ -0,0 +1,300 @@
+package quandl
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Aggregator combines the values in one period into one, e.g. the last
+// price or the total volume. It is never given an empty slice.
+type Aggregator func(values []float64) float64
+
+// Aggregators for Resample.
+var (
+	AggregateFirst Aggregator = func(v []float64) float64 { return v[0] }
+	AggregateLast  Aggregator = func(v []float64) float64 { return v[len(v)-1] }
+	AggregateMax   Aggregator = func(v []float64) float64 { return aggregate(v, math.Max) }
+	AggregateMin   Aggregator = func(v []float64) float64 { return aggregate(v, math.Min) }
+	AggregateSum   Aggregator = func(v []float64) float64 {
+		return aggregate(v, func(a, b float64) float64 { return a + b })
+	}
+	AggregateProduct Aggregator = func(v []float64) float64 {
+		return aggregate(v, func(a, b float64) float64 { return a * b })
+	}
+	AggregateMean Aggregator = func(v []float64) float64 { return AggregateSum(v) / float64(len(v)) }
+)
+
+func aggregate(values []float64, f func(float64, float64) float64) float64 {
+	result := values[0]
+	for _, v := range values[1:] {
+		result = f(result, v)
+	}
+
+	return result
+}
+
+// DefaultAggregator returns the aggregator Resample uses for a column with
+// none given: the first open, highest high, lowest low, total volume and
+// dividends, compounded split ratio and last value of anything else, such as
+// a close.
+func DefaultAggregator(column string) Aggregator {
+	switch strings.TrimPrefix(column, "Adj. ") {
+	case ColumnOpen:
+		return AggregateFirst
+	case ColumnHigh:
+		return AggregateMax
+	case ColumnLow:
+		return AggregateMin
+	case ColumnVolume, ColumnExDividend:
+		return AggregateSum
+	case ColumnSplitRatio:
+		return AggregateProduct
+	}
+
+	return AggregateLast
+}
+
+// Label is which date a resampled period is labelled with.
+type Label int
+
+const (
+	PeriodEnd   Label = iota // the last day of the period, e.g. 2016-01-31
+	PeriodStart              // the first day of the period, e.g. 2016-01-01
+)
+
+// ResampleOptions configures Resample.
+type ResampleOptions struct {
+	// Frequency is Weekly, Monthly, Quarterly, SemiAnnual or Annual.
+	Frequency Frequency
+
+	// WeekEnd is the last day of each week when resampling to Weekly, e.g.
+	// time.Friday. The zero value is Sunday.
+	WeekEnd time.Weekday
+
+	// Label is which date each period is labelled with.
+	Label Label
+}
+
+// period returns the first and last day of the period containing t.
+func (opts ResampleOptions) period(t time.Time) (time.Time, time.Time, error) {
+	year, month, _ := t.Date()
+	monthStart := func(m time.Month) time.Time {
+		return time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	var start time.Time
+	var months int
+	switch opts.Frequency {
+	case Weekly:
+		days := (int(opts.WeekEnd) - int(t.Weekday()) + 7) % 7
+		end := t.AddDate(0, 0, days)
+		return end.AddDate(0, 0, -6), end, nil
+	case Monthly:
+		start, months = monthStart(month), 1
+	case Quarterly:
+		start, months = monthStart((month-1)/3*3+1), 3
+	case SemiAnnual:
+		start, months = monthStart((month-1)/6*6+1), 6
+	case Annual:
+		start, months = monthStart(time.January), 12
+	default:
+		return time.Time{}, time.Time{}, fmt.Errorf("quandl: cannot resample to %v", opts.Frequency)
+	}
+
+	return start, start.AddDate(0, months, -1), nil
+}
+
+// group splits dates, oldest first, into periods. It returns the label of
+// each period and the index of its first date.
+func (opts ResampleOptions) group(dates []string) ([]string, []int, error) {
+	var labels []string
+	var starts []int
+	var current time.Time
+
+	for i, date := range dates {
+		t, err := time.Parse(dateFormat, date)
+		if err != nil {
+			return nil, nil, fmt.Errorf("quandl: cannot read %q as a date", date)
+		}
+
+		start, end, err := opts.period(t)
+		if err != nil {
+			return nil, nil, err
+		}
+		if i > 0 && start.Equal(current) {
+			continue
+		}
+		current = start
+
+		label := end
+		if opts.Label == PeriodStart {
+			label = start
+		}
+		labels = append(labels, label.Format(dateFormat))
+		starts = append(starts, i)
+	}
+
+	return labels, starts, nil
+}
+
+// Resample aggregates the series into periods, e.g. monthly averages with
+// AggregateMean. Missing values, which are NaN, are left out of the
+// aggregation, and a period with none is NaN.
+func (s Series) Resample(opts ResampleOptions, agg Aggregator) (Series, error) {
+	labels, starts, err := opts.group(s.Dates)
+	if err != nil {
+		return Series{}, err
+	}
+
+	resampled := Series{Dates: labels, Values: make([]float64, len(labels))}
+	for p, start := range starts {
+		end := s.Len()
+		if p+1 < len(starts) {
+			end = starts[p+1]
+		}
+
+		resampled.Values[p] = aggregatePresent(s.Values[start:end], agg)
+	}
+
+	return resampled, nil
+}
+
+// Resample aggregates the bars into periods: the first open, highest high,
+// lowest low, last close and total volume of each. Missing values are left
+// out, and are NaN in a period with none.
+func (b Bars) Resample(opts ResampleOptions) (Bars, error) {
+	dates := make([]string, len(b))
+	for i, bar := range b {
+		dates[i] = bar.Date
+	}
+
+	labels, starts, err := opts.group(dates)
+	if err != nil {
+		return nil, err
+	}
+
+	resampled := make(Bars, len(labels))
+	for p, start := range starts {
+		end := len(b)
+		if p+1 < len(starts) {
+			end = starts[p+1]
+		}
+
+		in := b[start:end]
+		resampled[p] = Bar{
+			Date:   labels[p],
+			Open:   in.aggregate(func(bar Bar) float64 { return bar.Open }, AggregateFirst),
+			High:   in.aggregate(func(bar Bar) float64 { return bar.High }, AggregateMax),
+			Low:    in.aggregate(func(bar Bar) float64 { return bar.Low }, AggregateMin),
+			Close:  in.aggregate(func(bar Bar) float64 { return bar.Close }, AggregateLast),
+			Volume: in.aggregate(func(bar Bar) float64 { return bar.Volume }, AggregateSum),
+		}
+	}
+
+	return resampled, nil
+}
+
+// aggregate combines one field of the bars, see aggregatePresent.
+func (b Bars) aggregate(field func(Bar) float64, agg Aggregator) float64 {
+	values := make([]float64, len(b))
+	for i, bar := range b {
+		values[i] = field(bar)
+	}
+
+	return aggregatePresent(values, agg)
+}
+
+// aggregatePresent aggregates values, leaving out missing ones, which are
+// NaN. It returns NaN if they are all missing.
+func aggregatePresent(values []float64, agg Aggregator) float64 {
+	var present []float64
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			present = append(present, v)
+		}
+	}
+	if len(present) == 0 {
+		return math.NaN()
+	}
+
+	return agg(present)
+}
+
+// Resample aggregates every column into periods, returning a response of
+// the same shape, newest first as Quandl returns it. aggregators gives the
+// aggregator for a column by name; columns without one use
+// DefaultAggregator. Missing values are left out of the aggregation, and a
+// period with none is missing too. Unlike the collapse parameter of the
+// Quandl API, which only takes the last value, this can sum volumes or
+// average rates.
+func (q *QuandlResponse) Resample(opts ResampleOptions, aggregators map[string]Aggregator) (*QuandlResponse, error) {
+	resampled := *q
+	resampled.Frequency = strings.ToLower(opts.Frequency.String())
+	resampled.Data = nil
+
+	if q.Data == nil {
+		return &resampled, nil
+	}
+
+	rows, err := q.rows()
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].date < rows[j].date })
+
+	dates := make([]string, len(rows))
+	for k, r := range rows {
+		dates[k] = r.date
+	}
+
+	labels, starts, err := opts.group(dates)
+	if err != nil {
+		return nil, err
+	}
+
+	dateColumnNum := q.getColumnNum("Date")
+	data := make([]interface{}, len(labels))
+	for p := range labels {
+		start, end := starts[p], len(rows)
+		if p+1 < len(starts) {
+			end = starts[p+1]
+		}
+
+		out := make([]interface{}, len(q.Columns))
+		for i, column := range q.Columns {
+			if i == dateColumnNum {
+				out[i] = labels[p]
+				continue
+			}
+
+			var values []float64
+			for _, in := range rows[start:end] {
+				value, ok, err := in.float(i, column)
+				if err != nil {
+					return nil, err
+				}
+				if ok {
+					values = append(values, value)
+				}
+			}
+			if len(values) == 0 {
+				continue
+			}
+
+			agg, ok := aggregators[column]
+			if !ok {
+				agg = DefaultAggregator(column)
+			}
+			out[i] = agg(values)
+		}
+
+		// Newest first, as Quandl returns data
+		data[len(labels)-1-p] = out
+	}
+	resampled.Data = data
+
+	return &resampled, nil
+}
@@ -0,0 +1,71 @@
+package quandl
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+)
+
+func ExampleSeries_Resample() {
+	rates, _ := NewSeries(
+		[]string{"2016-01-29", "2016-01-04", "2016-02-01", "2016-03-31", "2016-04-01"},
+		[]float64{0.34, 0.36, 0.38, 0.35, 0.29},
+	)
+
+	monthly, err := rates.Resample(ResampleOptions{Frequency: Monthly}, AggregateMean)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(monthly.Dates, monthly.Values)
+
+	quarterly, _ := rates.Resample(ResampleOptions{Frequency: Quarterly, Label: PeriodStart}, AggregateLast)
+	fmt.Println(quarterly.Dates, quarterly.Values)
+
+	// Gaps are left out, and a month of them is NaN
+	gappy, _ := NewSeries(
+		[]string{"2016-01-04", "2016-01-05", "2016-02-01"},
+		[]float64{0.36, math.NaN(), math.NaN()},
+	)
+	monthly, _ = gappy.Resample(ResampleOptions{Frequency: Monthly}, AggregateMean)
+	fmt.Println(monthly.Values)
+
+	_, err = rates.Resample(ResampleOptions{Frequency: Daily}, AggregateLast)
+	fmt.Println(err)
+
+	// Output:
+	// [2016-01-31 2016-02-29 2016-03-31 2016-04-30] [0.35 0.38 0.35 0.29]
+	// [2016-01-01 2016-04-01] [0.35 0.29]
+	// [0.36 NaN]
+	// quandl: cannot resample to Daily
+}
+
+func ExampleQuandlResponse_Resample() {
+	var q QuandlResponse
+	json.Unmarshal([]byte(wikiDataset), &q)
+
+	// 2014-06-05 is a Thursday, so weeks ending on Friday split it into the
+	// week of the 6th and the week of the 13th
+	opts := ResampleOptions{Frequency: Weekly, WeekEnd: time.Friday}
+
+	weekly, err := q.Resample(opts, nil)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(weekly.Frequency)
+	for _, row := range weekly.Data.([]interface{}) {
+		fmt.Println(row.([]interface{})[:8])
+	}
+
+	bars, _ := q.OHLCV(false)
+	weeks, _ := bars.Resample(opts)
+	fmt.Printf("%+v\n", weeks)
+
+	// Output:
+	// weekly
+	// [2014-06-13 49.5 52 49 51 3800 0.5 1]
+	// [2014-06-06 99 100 49.5 50 3200 0 2]
+	// [{Date:2014-06-06 Open:99 High:100 Low:49.5 Close:50 Volume:3200} {Date:2014-06-13 Open:49.5 High:52 Low:49 Close:51 Volume:3800}]
+}
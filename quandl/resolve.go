@@ -0,0 +1,151 @@
+package quandl
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrTickerNotFound is returned by Resolve when no code has the ticker.
+var ErrTickerNotFound = errors.New("quandl: ticker not found")
+
+// ResolveOptions narrows down the codes a ticker can resolve to. The zero
+// value allows any exchange and asset class.
+type ResolveOptions struct {
+	Exchange   string     // e.g. NYSE, NASDAQ or NYSEARCA
+	AssetClass AssetClass // UnknownAssetClass allows any
+}
+
+// Candidate is a Quandl code a ticker may refer to, with a score that ranks
+// it against the others.
+type Candidate struct {
+	Security
+	Score int
+}
+
+// AmbiguousTickerError is returned by Resolve when more than one code is an
+// equally good match for a ticker. Pass an exchange or asset class in
+// ResolveOptions to choose between them.
+type AmbiguousTickerError struct {
+	Ticker     string
+	Candidates []Candidate
+}
+
+func (e *AmbiguousTickerError) Error() string {
+	codes := make([]string, len(e.Candidates))
+	for i, candidate := range e.Candidates {
+		codes[i] = candidate.Code
+	}
+
+	return fmt.Sprintf("quandl: ticker %q is ambiguous, it could be %s", e.Ticker, strings.Join(codes, ", "))
+}
+
+// Scores added for each thing in a code's favour. Matching the requested
+// exchange outweighs everything else, then WIKI is preferred to Google
+// Finance because its prices are adjusted for splits and dividends. Codes
+// not known to be delisted get scoreActive.
+const (
+	scoreExchange = 10
+	scoreWiki     = 3
+	scoreGoogle   = 2
+	scoreOther    = 1
+	scoreActive   = 1
+)
+
+// Candidates returns every code in the catalog for ticker that satisfies
+// opts, best first.
+func (c *Catalog) Candidates(ticker string, opts ResolveOptions) []Candidate {
+	var candidates []Candidate
+
+	for _, s := range c.ByTicker(ticker) {
+		if opts.AssetClass != UnknownAssetClass && s.AssetClass != opts.AssetClass {
+			continue
+		}
+
+		score := scoreOther
+		switch {
+		case strings.HasPrefix(s.Code, "WIKI/"):
+			score = scoreWiki
+		case strings.HasPrefix(s.Code, "GOOG/"):
+			score = scoreGoogle
+		}
+
+		if opts.Exchange != "" {
+			if strings.EqualFold(s.Exchange, opts.Exchange) {
+				score += scoreExchange
+			} else if s.Exchange != "" {
+				continue
+			}
+		}
+
+		if !s.Delisted {
+			score += scoreActive
+		}
+
+		candidates = append(candidates, Candidate{Security: s, Score: score})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].Code < candidates[j].Code
+	})
+
+	return candidates
+}
+
+// Resolve returns the Quandl code for ticker. It returns ErrTickerNotFound if
+// there is none and an *AmbiguousTickerError if the best candidates tie.
+func (c *Catalog) Resolve(ticker string, opts ResolveOptions) (string, error) {
+	candidates := c.Candidates(ticker, opts)
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("%w: %q", ErrTickerNotFound, ticker)
+	}
+
+	tied := 1
+	for tied < len(candidates) && candidates[tied].Score == candidates[0].Score {
+		tied++
+	}
+	if tied > 1 {
+		return "", &AmbiguousTickerError{Ticker: ticker, Candidates: candidates[:tied]}
+	}
+
+	return candidates[0].Code, nil
+}
+
+// Resolve maps a plain ticker such as AAPL or SPY to its Quandl code, e.g.
+// WIKI/AAPL or GOOG/NYSEARCA_SPY
+func Resolve(ticker string, opts ResolveOptions) (string, error) {
+	return defaultClient().Resolve(ticker, opts)
+}
+
+// Resolve maps a plain ticker such as AAPL or SPY to its Quandl code, e.g.
+// WIKI/AAPL or GOOG/NYSEARCA_SPY. The catalog is loaded on first use and
+// kept by the client.
+func (c *Client) Resolve(ticker string, opts ResolveOptions) (string, error) {
+	catalog, err := c.Catalog()
+	if err != nil {
+		return "", err
+	}
+
+	return catalog.Resolve(ticker, opts)
+}
+
+// Catalog returns the client's catalog, assembling it with GetCatalog the
+// first time it is needed. A failed load is retried on the next call.
+func (c *Client) Catalog() (*Catalog, error) {
+	c.catalogMu.Lock()
+	defer c.catalogMu.Unlock()
+
+	if c.catalog == nil {
+		catalog, err := c.GetCatalog()
+		if err != nil {
+			return nil, err
+		}
+		c.catalog = catalog
+	}
+
+	return c.catalog, nil
+}
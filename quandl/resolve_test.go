@@ -0,0 +1,40 @@
+package quandl
+
+import (
+	"errors"
+	"fmt"
+)
+
+func ExampleResolve() {
+	catalog := NewCatalog([]Security{
+		{Code: "WIKI/AAPL", Ticker: "AAPL", AssetClass: Stock, Active: true},
+		{Code: "GOOG/NASDAQ_AAPL", Ticker: "AAPL", AssetClass: Stock, Exchange: "NASDAQ", Active: true},
+		{Code: "GOOG/NYSEARCA_SPY", Ticker: "SPY", AssetClass: ETF, Exchange: "NYSEARCA", Active: true},
+		{Code: "GOOG/NYSE_BRK_A", Ticker: "BRK_A", AssetClass: Stock, Exchange: "NYSE", Active: true},
+		{Code: "GOOG/AMEX_BRK_A", Ticker: "BRK_A", AssetClass: Stock, Exchange: "AMEX", Delisted: true},
+		{Code: "GOOG/NYSE_X", Ticker: "X", AssetClass: Stock, Exchange: "NYSE", Active: true},
+		{Code: "GOOG/NASDAQ_X", Ticker: "X", AssetClass: Stock, Exchange: "NASDAQ", Active: true},
+	})
+
+	fmt.Println(catalog.Resolve("aapl", ResolveOptions{}))
+	fmt.Println(catalog.Resolve("AAPL", ResolveOptions{Exchange: "NASDAQ"}))
+	fmt.Println(catalog.Resolve("SPY", ResolveOptions{}))
+	fmt.Println(catalog.Resolve("BRK_A", ResolveOptions{}))
+
+	_, err := catalog.Resolve("X", ResolveOptions{})
+	var ambiguous *AmbiguousTickerError
+	fmt.Println(errors.As(err, &ambiguous), err)
+	fmt.Println(catalog.Resolve("X", ResolveOptions{Exchange: "NYSE"}))
+
+	_, err = catalog.Resolve("MSFT", ResolveOptions{})
+	fmt.Println(errors.Is(err, ErrTickerNotFound))
+
+	// Output:
+	// WIKI/AAPL <nil>
+	// GOOG/NASDAQ_AAPL <nil>
+	// GOOG/NYSEARCA_SPY <nil>
+	// GOOG/NYSE_BRK_A <nil>
+	// true quandl: ticker "X" is ambiguous, it could be GOOG/NASDAQ_X, GOOG/NYSE_X
+	// GOOG/NYSE_X <nil>
+	// true
+}
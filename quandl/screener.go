@@ -0,0 +1,187 @@
+package quandl
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ParseRatio returns the ratio with the given code, e.g. PE_CURR, ignoring
+// case.
+func ParseRatio(s string) (Ratio, error) {
+	for _, info := range ratioInfo {
+		if strings.EqualFold(s, string(info.ratio)) {
+			return info.ratio, nil
+		}
+	}
+
+	return "", fmt.Errorf("quandl: unknown ratio %q", s)
+}
+
+// Criterion is one condition of a screen, e.g. PE_CURR < 15.
+type Criterion struct {
+	Ratio Ratio
+	Op    string // one of <, <=, >, >=, = and !=
+	Value float64
+}
+
+func (c Criterion) String() string {
+	return fmt.Sprintf("%s %s %s", c.Ratio, c.Op, strconv.FormatFloat(c.Value, 'g', -1, 64))
+}
+
+// Match reports whether value satisfies the criterion. A negative multiple,
+// such as the PE of a company making a loss, never meets an upper bound: a
+// PE of -50 is not cheaper than one of 10.
+func (c Criterion) Match(value float64) bool {
+	if (c.Op == "<" || c.Op == "<=") && value < 0 && c.Ratio.Unit() == UnitMultiple {
+		return false
+	}
+
+	switch c.Op {
+	case "<":
+		return value < c.Value
+	case "<=":
+		return value <= c.Value
+	case ">":
+		return value > c.Value
+	case ">=":
+		return value >= c.Value
+	case "=":
+		return value == c.Value
+	case "!=":
+		return value != c.Value
+	}
+
+	return false
+}
+
+// margin is how far value clears the threshold, relative to the threshold,
+// so that criteria on different scales count the same when ranking.
+func (c Criterion) margin(value float64) float64 {
+	scale := math.Abs(c.Value)
+	if scale == 0 {
+		scale = 1
+	}
+
+	switch c.Op {
+	case "<", "<=":
+		return (c.Value - value) / scale
+	case ">", ">=":
+		return (value - c.Value) / scale
+	}
+
+	return 0
+}
+
+var (
+	criteriaSeparator = regexp.MustCompile(`(?i)\s+and\s+|,`)
+	criterionPattern  = regexp.MustCompile(`^\s*([A-Za-z0-9_]+)\s*(<=|>=|!=|<|>|=)\s*(\S+)\s*$`)
+)
+
+// ParseCriteria parses criteria such as "PE_CURR < 15 AND ROE > 0.1". The
+// criteria are joined by AND or commas and all must be met.
+func ParseCriteria(expr string) ([]Criterion, error) {
+	var criteria []Criterion
+
+	for _, part := range criteriaSeparator.Split(expr, -1) {
+		m := criterionPattern.FindStringSubmatch(part)
+		if m == nil {
+			return nil, fmt.Errorf("quandl: cannot parse criterion %q", strings.TrimSpace(part))
+		}
+
+		ratio, err := ParseRatio(m[1])
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := strconv.ParseFloat(m[3], 64)
+		if err != nil {
+			return nil, fmt.Errorf("quandl: cannot parse %q in criterion %q", m[3], strings.TrimSpace(part))
+		}
+
+		criteria = append(criteria, Criterion{Ratio: ratio, Op: m[2], Value: value})
+	}
+
+	return criteria, nil
+}
+
+// ScreenResult is a ticker that met every criterion of a screen.
+type ScreenResult struct {
+	Ticker string
+	Values map[Ratio]float64 // the latest value of each ratio screened on
+	Dates  map[Ratio]string  // the date of each value
+	Score  float64           // how comfortably the criteria were met
+}
+
+// Screen returns the tickers whose latest values meet every criterion, best
+// first. A ticker is ranked by the sum of how far each value clears its
+// threshold, relative to the threshold. Tickers missing a ratio never match,
+// and neither do negative multiples screened with an upper bound.
+func (p *Panel) Screen(criteria []Criterion) []ScreenResult {
+	var results []ScreenResult
+
+	for _, ticker := range p.Tickers {
+		result := ScreenResult{
+			Ticker: ticker,
+			Values: make(map[Ratio]float64, len(criteria)),
+			Dates:  make(map[Ratio]string, len(criteria)),
+		}
+
+		matched := true
+		for _, c := range criteria {
+			date, value, ok := p.Latest(ticker, c.Ratio)
+			if !ok || !c.Match(value) {
+				matched = false
+				break
+			}
+
+			result.Values[c.Ratio] = value
+			result.Dates[c.Ratio] = date
+			result.Score += c.margin(value)
+		}
+
+		if matched {
+			results = append(results, result)
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+
+	return results
+}
+
+// Screen fetches the ratios named in expr for every ticker in universe and
+// returns the tickers that meet all the criteria, best first
+func Screen(universe []string, expr string) ([]ScreenResult, error) {
+	return defaultClient().Screen(universe, expr)
+}
+
+// Screen fetches the ratios named in expr for every ticker in universe and
+// returns the tickers that meet all the criteria, best first. Use WithCache
+// to avoid fetching the ratios again on every screen. Tickers whose ratios
+// cannot be fetched do not match, and the errors are returned with the
+// results.
+func (c *Client) Screen(universe []string, expr string) ([]ScreenResult, error) {
+	criteria, err := ParseCriteria(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	var ratios []Ratio
+	seen := make(map[Ratio]bool)
+	for _, criterion := range criteria {
+		if !seen[criterion.Ratio] {
+			seen[criterion.Ratio] = true
+			ratios = append(ratios, criterion.Ratio)
+		}
+	}
+
+	panel, err := c.GetFundamentals(universe, ratios)
+
+	return panel.Screen(criteria), err
+}
@@ -0,0 +1,43 @@
+package quandl
+
+import "fmt"
+
+func ExampleScreen() {
+	mirror := newMirror(dmdrnFiles)
+	defer mirror.Close()
+
+	c, err := NewClient(WithoutConfig(), WithMirror(mirror.URL))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	// LOSS has no PE and NEG a negative one, so neither is cheap
+	results, err := c.Screen([]string{"AAPL", "XOM", "LOSS", "NEG"}, "PE_CURR < 20 AND roe > 0.05")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for _, r := range results {
+		fmt.Printf("%s %.2f PE_CURR=%v ROE=%v\n", r.Ticker, r.Score, r.Values[RatioCurrentPE], r.Values[RatioReturnOnEquity])
+	}
+
+	results, _ = c.Screen([]string{"AAPL", "XOM"}, "PE_CURR <= 15, ROE >= 0.1")
+	fmt.Println(len(results), results[0].Ticker, results[0].Dates[RatioCurrentPE])
+
+	// Output:
+	// AAPL 8.54 PE_CURR=13.2 ROE=0.46
+	// XOM 0.81 PE_CURR=19.8 ROE=0.09
+	// 1 AAPL 2015-12-31
+}
+
+func ExampleParseCriteria() {
+	fmt.Println(ParseCriteria("PE_CURR < 15 AND ROE > 0.1"))
+	fmt.Println(ParseCriteria("PE < 15"))
+	fmt.Println(ParseCriteria("ROE between 1 and 2"))
+
+	// Output:
+	// [PE_CURR < 15 ROE > 0.1] <nil>
+	// [] quandl: unknown ratio "PE"
+	// [] quandl: cannot parse criterion "ROE between 1"
+}
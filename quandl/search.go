@@ -0,0 +1,290 @@
+package quandl
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Document is one entry in a SearchIndex.
+type Document struct {
+	Code        string // Quandl code, e.g. WIKI/AAPL or FRED/GDP
+	Ticker      string
+	Description string
+}
+
+// SearchResult is a document that matched a query, with its relevance.
+type SearchResult struct {
+	Document
+	Score float64
+}
+
+// How much a query term counts for when it matches a document term exactly,
+// as a prefix or with typos. An exact ticker match outranks any description.
+const (
+	weightExact       = 1.0
+	weightPrefix      = 0.7
+	weightOneTypo     = 0.5
+	weightTwoTypos    = 0.3
+	weightTickerMatch = 10.0
+)
+
+// typoWeights is the weight of a match by the number of typos in it.
+var typoWeights = []float64{weightExact, weightOneTypo, weightTwoTypos}
+
+// SearchIndex is an in-memory full text index over code descriptions for
+// searching without a request to Quandl. Every query term must match a
+// document term exactly, as a prefix or, for longer terms, with a typo or
+// two. Documents are ranked by how rare their matching terms are.
+type SearchIndex struct {
+	documents []Document
+	postings  map[string][]int // term to the documents it is in
+	terms     []string         // sorted, for prefix lookups
+}
+
+// NewSearchIndex indexes the ticker and description of each document.
+func NewSearchIndex(documents []Document) *SearchIndex {
+	idx := &SearchIndex{
+		documents: documents,
+		postings:  make(map[string][]int),
+	}
+
+	for i, d := range documents {
+		seen := make(map[string]bool)
+		for _, term := range tokenize(d.Ticker + " " + d.Description) {
+			if seen[term] {
+				continue
+			}
+			seen[term] = true
+			idx.postings[term] = append(idx.postings[term], i)
+		}
+	}
+
+	idx.terms = make([]string, 0, len(idx.postings))
+	for term := range idx.postings {
+		idx.terms = append(idx.terms, term)
+	}
+	sort.Strings(idx.terms)
+
+	return idx
+}
+
+// Len returns the number of documents in the index.
+func (idx *SearchIndex) Len() int {
+	return len(idx.documents)
+}
+
+// Search returns up to limit documents matching query, best first. A limit
+// of 0 or less returns every match.
+func (idx *SearchIndex) Search(query string, limit int) []SearchResult {
+	queryTerms := tokenize(query)
+	if len(queryTerms) == 0 {
+		return nil
+	}
+
+	var scores map[int]float64
+	for _, q := range queryTerms {
+		termScores := idx.match(q)
+
+		if scores == nil {
+			scores = termScores
+			continue
+		}
+
+		for doc, score := range scores {
+			if s, ok := termScores[doc]; ok {
+				scores[doc] = score + s
+			} else {
+				delete(scores, doc)
+			}
+		}
+	}
+
+	results := make([]SearchResult, 0, len(scores))
+	for doc, score := range scores {
+		d := idx.documents[doc]
+		if strings.EqualFold(d.Ticker, strings.TrimSpace(query)) {
+			score += weightTickerMatch
+		}
+		results = append(results, SearchResult{Document: d, Score: score})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if len(a.Description) != len(b.Description) {
+			return len(a.Description) < len(b.Description)
+		}
+		return a.Code < b.Code
+	})
+
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results
+}
+
+// match scores every document containing a term that matches q, keeping the
+// best match for each document.
+func (idx *SearchIndex) match(q string) map[int]float64 {
+	scores := make(map[int]float64)
+
+	add := func(term string, weight float64) {
+		docs := idx.postings[term]
+		score := weight * math.Log(1+float64(len(idx.documents))/float64(len(docs)))
+		for _, doc := range docs {
+			if score > scores[doc] {
+				scores[doc] = score
+			}
+		}
+	}
+
+	for i := sort.SearchStrings(idx.terms, q); i < len(idx.terms) && strings.HasPrefix(idx.terms[i], q); i++ {
+		if idx.terms[i] == q {
+			add(q, weightExact)
+		} else {
+			add(idx.terms[i], weightPrefix)
+		}
+	}
+
+	maxTypos := allowedTypos(q)
+	if maxTypos == 0 {
+		return scores
+	}
+
+	for _, term := range idx.terms {
+		if abs(len(term)-len(q)) > maxTypos || strings.HasPrefix(term, q) {
+			continue
+		}
+
+		if d := editDistance(q, term, maxTypos); d <= maxTypos {
+			add(term, typoWeights[d])
+		}
+	}
+
+	return scores
+}
+
+// allowedTypos is how many edits a query term may be from a document term.
+// Short terms must be exact, since one edit turns them into other words.
+func allowedTypos(q string) int {
+	switch n := len([]rune(q)); {
+	case n >= 8:
+		return 2
+	case n >= 4:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// tokenize lower cases s and splits it into runs of letters and digits.
+func tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// editDistance returns the number of insertions, deletions, substitutions
+// and adjacent transpositions that turn a into b, or max+1 once it is clear
+// the distance is more than max.
+func editDistance(a string, b string, max int) int {
+	s, t := []rune(a), []rune(b)
+
+	prev2 := make([]int, len(t)+1)
+	prev := make([]int, len(t)+1)
+	cur := make([]int, len(t)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		cur[0] = i
+		best := cur[0]
+
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+			best = min(best, cur[j])
+		}
+
+		if best > max {
+			return max + 1
+		}
+
+		prev2, prev, cur = prev, cur, prev2
+	}
+
+	return prev[len(t)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
+
+// SearchOffline searches the descriptions of every security in the catalog
+// and every FRED series without querying the Quandl API. The index is built
+// on first use and kept by the default client
+func SearchOffline(query string, limit int) ([]SearchResult, error) {
+	return defaultClient().SearchOffline(query, limit)
+}
+
+// SearchOffline searches the descriptions of every security in the catalog
+// and every FRED series without querying the Quandl API. The index is built
+// on first use and kept by the client
+func (c *Client) SearchOffline(query string, limit int) ([]SearchResult, error) {
+	idx, err := c.SearchIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	return idx.Search(query, limit), nil
+}
+
+// SearchIndex returns the index used by SearchOffline, building it from the
+// client's catalog and the FRED list the first time it is needed.
+func (c *Client) SearchIndex() (*SearchIndex, error) {
+	catalog, err := c.Catalog()
+	if err != nil {
+		return nil, err
+	}
+
+	c.catalogMu.Lock()
+	defer c.catalogMu.Unlock()
+
+	if c.searchIndex != nil {
+		return c.searchIndex, nil
+	}
+
+	economic, err := c.LoadList(c.endpoints.EconomicData, economicListMapping)
+	if err != nil {
+		return nil, err
+	}
+
+	documents := make([]Document, 0, catalog.Len()+len(economic))
+	for _, s := range catalog.Securities() {
+		documents = append(documents, Document{Code: s.Code, Ticker: s.Ticker, Description: s.Name})
+	}
+	for _, r := range economic {
+		documents = append(documents, Document{Code: "FRED/" + r[FieldCode], Description: r[FieldName]})
+	}
+
+	c.searchIndex = NewSearchIndex(documents)
+
+	return c.searchIndex, nil
+}
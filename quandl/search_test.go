@@ -0,0 +1,29 @@
+package quandl
+
+import "fmt"
+
+func ExampleSearchIndex() {
+	idx := NewSearchIndex([]Document{
+		{Code: "WIKI/AAPL", Ticker: "AAPL", Description: "Apple Inc."},
+		{Code: "WIKI/APOG", Ticker: "APOG", Description: "Apogee Enterprises Inc."},
+		{Code: "GOOG/NYSEARCA_SPY", Ticker: "SPY", Description: "SPDR S&P 500 ETF Trust"},
+		{Code: "FRED/GDP", Description: "Gross Domestic Product"},
+		{Code: "FRED/LAUR", Description: "Unemployment Rate in Louisiana"},
+		{Code: "FRED/UNRATE", Description: "Civilian Unemployment Rate"},
+	})
+
+	for _, query := range []string{"aapl", "ap", "unemployment", "unemplyment louisiana", "gross domestc"} {
+		fmt.Printf("%s:", query)
+		for _, r := range idx.Search(query, 3) {
+			fmt.Printf(" %s", r.Code)
+		}
+		fmt.Println()
+	}
+
+	// Output:
+	// aapl: WIKI/AAPL
+	// ap: WIKI/AAPL WIKI/APOG
+	// unemployment: FRED/UNRATE FRED/LAUR
+	// unemplyment louisiana: FRED/LAUR
+	// gross domestc: FRED/GDP
+}
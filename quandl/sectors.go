@@ -0,0 +1,257 @@
+package quandl
+
+import (
+	"math"
+	"sort"
+)
+
+// tradingDaysPerYear annualises the volatility of daily returns.
+const tradingDaysPerYear = 252
+
+// indexBase is the level sector indices start at.
+const indexBase = 100
+
+// SectorIndex is the aggregate performance of the members of one sector.
+type SectorIndex struct {
+	Sector  string
+	Members []string
+	Dates   []string
+
+	// Index levels on each date, starting at 100. EqualWeighted is rebalanced
+	// to equal weights every day, CapWeighted holds the members in proportion
+	// to their market caps at the start and is nil without caps.
+	EqualWeighted []float64
+	CapWeighted   []float64
+
+	// Return attribution for the period. Weight is the sector's share of the
+	// universe at the start, by market cap if given and otherwise by number
+	// of members, and Return its buy and hold return with the same
+	// weighting. The Contributions of all sectors add up to the return of
+	// the universe.
+	Weight       float64
+	Return       float64
+	Contribution float64
+
+	// Statistics of the daily returns of the cap weighted index, or the equal
+	// weighted one without caps.
+	Volatility  float64 // annualised standard deviation
+	MaxDrawdown float64 // largest fall from a peak, e.g. 0.2 for 20%
+
+	// The members with the best and worst returns over the period.
+	Best  string
+	Worst string
+}
+
+// AggregateSectors groups prices by sector and builds an index and summary
+// statistics for each sector from start to end inclusive. prices, sectors
+// and caps are keyed the same way, e.g. by the Quandl codes returned by
+// GetSP500SectorMappings. caps are market caps at the start of the period
+// and may be nil, in which case only equal weighted figures are produced;
+// members without a cap are left out of the cap weighted figures. Members
+// with no sector or no prices in the period are skipped, as are prices that
+// are missing (NaN) or 0. Sectors are returned in name order.
+func AggregateSectors(prices map[string]Series, sectors map[string]string, caps map[string]float64, start string, end string) []SectorIndex {
+	members := make(map[string][]string)
+	periods := make(map[string]Series)
+	for key, sector := range sectors {
+		if sector == "" {
+			continue
+		}
+		if s := validPrices(prices[key].Between(start, end)); s.Len() > 0 {
+			members[sector] = append(members[sector], key)
+			periods[key] = s
+		}
+	}
+
+	names := make([]string, 0, len(members))
+	for sector := range members {
+		names = append(names, sector)
+		sort.Strings(members[sector])
+	}
+	sort.Strings(names)
+
+	var total float64
+	for _, sector := range names {
+		total += sectorWeight(members[sector], caps)
+	}
+
+	indices := make([]SectorIndex, 0, len(names))
+	for _, sector := range names {
+		series := make([]Series, len(members[sector]))
+		for i, key := range members[sector] {
+			series[i] = periods[key]
+		}
+
+		index := aggregateSector(sector, members[sector], series, caps)
+		if total > 0 {
+			index.Weight = sectorWeight(index.Members, caps) / total
+		}
+		index.Contribution = index.Weight * index.Return
+		indices = append(indices, index)
+	}
+
+	return indices
+}
+
+// validPrices returns the observations of s that can be used as prices,
+// leaving out missing (NaN) and zero values, which would otherwise be read
+// as a fall of 100%.
+func validPrices(s Series) Series {
+	valid := Series{}
+	for i, value := range s.Values {
+		if value != 0 && !math.IsNaN(value) {
+			valid.Dates = append(valid.Dates, s.Dates[i])
+			valid.Values = append(valid.Values, value)
+		}
+	}
+
+	return valid
+}
+
+// sectorWeight is the total market cap of members, or their number without
+// caps.
+func sectorWeight(members []string, caps map[string]float64) float64 {
+	if caps == nil {
+		return float64(len(members))
+	}
+
+	var weight float64
+	for _, key := range members {
+		weight += caps[key]
+	}
+
+	return weight
+}
+
+func aggregateSector(sector string, members []string, series []Series, caps map[string]float64) SectorIndex {
+	index := SectorIndex{Sector: sector, Members: members}
+
+	seen := make(map[string]bool)
+	for _, s := range series {
+		for _, date := range s.Dates {
+			if !seen[date] {
+				seen[date] = true
+				index.Dates = append(index.Dates, date)
+			}
+		}
+	}
+	sort.Strings(index.Dates)
+
+	// Buy and hold returns, and the sector return weighted the same way as
+	// the sector weight
+	returns := make([]float64, len(members))
+	var weighted, weights float64
+	for i, s := range series {
+		returns[i] = s.Values[s.Len()-1]/s.Values[0] - 1
+
+		w := 1.0
+		if caps != nil {
+			w = caps[members[i]]
+		}
+		weighted += w * returns[i]
+		weights += w
+	}
+	if weights > 0 {
+		index.Return = weighted / weights
+	}
+
+	best, worst := 0, 0
+	for i, r := range returns {
+		if r > returns[best] {
+			best = i
+		}
+		if r < returns[worst] {
+			worst = i
+		}
+	}
+	index.Best, index.Worst = members[best], members[worst]
+
+	// Step through the dates, updating each member's last price as it has
+	// one and moving the indices by the day's average return
+	first := make([]float64, len(members))
+	last := make([]float64, len(members))
+	next := make([]int, len(members))
+	for i, s := range series {
+		first[i] = s.Values[0]
+	}
+
+	index.EqualWeighted = make([]float64, len(index.Dates))
+	if caps != nil {
+		index.CapWeighted = make([]float64, len(index.Dates))
+	}
+
+	equal, capped := float64(indexBase), float64(indexBase)
+	for d, date := range index.Dates {
+		var equalSum, capSum, capWeights float64
+		var n int
+
+		for i, s := range series {
+			if next[i] >= s.Len() || s.Dates[next[i]] != date {
+				continue
+			}
+			price := s.Values[next[i]]
+			next[i]++
+
+			if last[i] != 0 {
+				r := price/last[i] - 1
+				equalSum += r
+				n++
+
+				w := caps[members[i]] * last[i] / first[i]
+				capSum += w * r
+				capWeights += w
+			}
+			last[i] = price
+		}
+
+		if n > 0 {
+			equal *= 1 + equalSum/float64(n)
+		}
+		if capWeights > 0 {
+			capped *= 1 + capSum/capWeights
+		}
+
+		index.EqualWeighted[d] = equal
+		if caps != nil {
+			index.CapWeighted[d] = capped
+		}
+	}
+
+	levels := index.EqualWeighted
+	if caps != nil {
+		levels = index.CapWeighted
+	}
+	index.Volatility, index.MaxDrawdown = levelStatistics(levels)
+
+	return index
+}
+
+// levelStatistics returns the annualised volatility of the daily returns of
+// an index and its maximum drawdown.
+func levelStatistics(levels []float64) (float64, float64) {
+	var drawdown, peak float64
+	for _, level := range levels {
+		peak = math.Max(peak, level)
+		drawdown = math.Max(drawdown, 1-level/peak)
+	}
+
+	if len(levels) < 3 {
+		return 0, drawdown
+	}
+
+	returns := make([]float64, len(levels)-1)
+	var mean float64
+	for i := range returns {
+		returns[i] = levels[i+1]/levels[i] - 1
+		mean += returns[i]
+	}
+	mean /= float64(len(returns))
+
+	var variance float64
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+	}
+	variance /= float64(len(returns) - 1)
+
+	return math.Sqrt(variance * tradingDaysPerYear), drawdown
+}
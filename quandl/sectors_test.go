@@ -0,0 +1,61 @@
+package quandl
+
+import "fmt"
+
+func ExampleAggregateSectors() {
+	dates := []string{"2016-01-04", "2016-01-05", "2016-01-06", "2016-01-07"}
+	prices := map[string]Series{
+		"WIKI/AAPL": {Dates: dates, Values: []float64{100, 110, 121, 115}},
+		"WIKI/MSFT": {Dates: dates, Values: []float64{50, 50, 55, 55}},
+		"WIKI/XOM":  {Dates: dates, Values: []float64{80, 72, 76, 76}},
+	}
+	sectors := map[string]string{
+		"WIKI/AAPL": "Information Technology",
+		"WIKI/MSFT": "Information Technology",
+		"WIKI/XOM":  "Energy",
+	}
+	caps := map[string]float64{"WIKI/AAPL": 600, "WIKI/MSFT": 400, "WIKI/XOM": 300}
+
+	var total float64
+	for _, s := range AggregateSectors(prices, sectors, caps, "2016-01-04", "2016-01-06") {
+		fmt.Printf("%s %v best %s worst %s\n", s.Sector, s.Members, s.Best, s.Worst)
+		fmt.Printf("  equal %.2f cap %.2f\n", s.EqualWeighted, s.CapWeighted)
+		fmt.Printf("  weight %.3f return %.3f contribution %.3f drawdown %.3f\n", s.Weight, s.Return, s.Contribution, s.MaxDrawdown)
+		total += s.Contribution
+	}
+	fmt.Printf("universe %.3f\n", total)
+
+	// Output:
+	// Energy [WIKI/XOM] best WIKI/XOM worst WIKI/XOM
+	//   equal [100.00 90.00 95.00] cap [100.00 90.00 95.00]
+	//   weight 0.231 return -0.050 contribution -0.012 drawdown 0.100
+	// Information Technology [WIKI/AAPL WIKI/MSFT] best WIKI/AAPL worst WIKI/MSFT
+	//   equal [100.00 105.00 115.50] cap [100.00 106.00 116.60]
+	//   weight 0.769 return 0.166 contribution 0.128 drawdown 0.000
+	// universe 0.116
+}
+
+func ExampleAggregateSectors_missingPrices() {
+	// A day without a price is left out rather than read as 0
+	q := QuandlResponse{
+		Columns: []string{"Date", ColumnAdjClose},
+		Data: []interface{}{
+			[]interface{}{"2016-01-06", 110.0},
+			[]interface{}{"2016-01-05", nil},
+			[]interface{}{"2016-01-04", 100.0},
+		},
+	}
+	prices, err := q.Series(ColumnAdjClose)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	sectors := map[string]string{"WIKI/AAPL": "Information Technology"}
+	for _, s := range AggregateSectors(map[string]Series{"WIKI/AAPL": prices}, sectors, nil, "", "") {
+		fmt.Printf("%s %v %.2f return %.3f drawdown %.3f\n", s.Sector, s.Dates, s.EqualWeighted, s.Return, s.MaxDrawdown)
+	}
+
+	// Output:
+	// Information Technology [2016-01-04 2016-01-06] [100.00 110.00] return 0.100 drawdown 0.000
+}
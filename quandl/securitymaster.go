@@ -0,0 +1,90 @@
+package quandl
+
+import "strings"
+
+// SecurityMasterRecord is one row of the Quandl stock code list, with every
+// column preserved.
+type SecurityMasterRecord struct {
+	Ticker     string
+	Name       string
+	PriceCode  string // e.g. GOOG/NYSE_A
+	RatiosCode string // Damodaran ratios code, e.g. DMDRN/A_ALLFINANCIALRATIOS
+	InMarket   string // the "In Market?" column as given, e.g. Active or Delisted
+	Active     bool   // true if InMarket is Active
+}
+
+// Exchange returns the exchange in the price code, e.g. NYSE.
+func (r SecurityMasterRecord) Exchange() string {
+	return exchangeFromCode(r.PriceCode)
+}
+
+// Security converts the record to a catalog Security.
+func (r SecurityMasterRecord) Security() Security {
+	return Security{
+		Code:       r.PriceCode,
+		Ticker:     r.Ticker,
+		Name:       r.Name,
+		AssetClass: Stock,
+		Exchange:   r.Exchange(),
+		RatiosCode: r.RatiosCode,
+		Active:     r.Active,
+		Delisted:   r.InMarket != "" && !r.Active,
+	}
+}
+
+// SecurityMaster is every stock Quandl has prices for, including delisted
+// ones, so that universes can be built without survivorship bias.
+type SecurityMaster []SecurityMasterRecord
+
+// Active returns the records for stocks that are still trading.
+func (m SecurityMaster) Active() SecurityMaster {
+	return m.filter(true)
+}
+
+// Delisted returns the records for stocks that are no longer trading.
+func (m SecurityMaster) Delisted() SecurityMaster {
+	return m.filter(false)
+}
+
+func (m SecurityMaster) filter(active bool) SecurityMaster {
+	var filtered SecurityMaster
+	for _, r := range m {
+		if r.Active == active {
+			filtered = append(filtered, r)
+		}
+	}
+
+	return filtered
+}
+
+// GetSecurityMaster gets every row of the Quandl stock code list
+func GetSecurityMaster() (SecurityMaster, error) {
+	return defaultClient().GetSecurityMaster()
+}
+
+// GetSecurityMaster gets every row of the Quandl stock code list
+func (c *Client) GetSecurityMaster() (SecurityMaster, error) {
+	list, err := c.LoadList(c.endpoints.StockList, stockListMapping)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseSecurityMaster(list), nil
+}
+
+func parseSecurityMaster(list []Row) SecurityMaster {
+	var master SecurityMaster
+	for _, r := range list {
+		inMarket := r[FieldInMarket]
+		master = append(master, SecurityMasterRecord{
+			Ticker:     r[FieldTicker],
+			Name:       r[FieldName],
+			PriceCode:  r[FieldCode],
+			RatiosCode: r[FieldRatiosCode],
+			InMarket:   inMarket,
+			Active:     strings.EqualFold(inMarket, "Active"),
+		})
+	}
+
+	return master
+}
@@ -0,0 +1,28 @@
+package quandl
+
+import "fmt"
+
+func ExampleGetSecurityMaster() {
+	mirror := newMirror(mirrorFiles)
+	defer mirror.Close()
+
+	c, err := NewClient(WithoutConfig(), WithMirror(mirror.URL))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	master, err := c.GetSecurityMaster()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(len(master), len(master.Active()))
+	for _, r := range master.Delisted() {
+		fmt.Printf("%s %q %s %s %s %s\n", r.Ticker, r.Name, r.PriceCode, r.Exchange(), r.RatiosCode, r.InMarket)
+	}
+
+	// Output:
+	// 3 2
+	// LEH "Lehman Brothers" GOOG/NYSE_LEH NYSE DMDRN/LEH_ALLFINANCIALRATIOS Delisted
+}
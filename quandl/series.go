@@ -0,0 +1,82 @@
+package quandl
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Series is a time series with dates in the 2006-01-02 format, oldest first.
+type Series struct {
+	Dates  []string
+	Values []float64
+}
+
+// NewSeries makes a series from parallel dates and values in any order, such
+// as the newest first vectors returned by GetTimeSeries.
+func NewSeries(dates []string, values []float64) (Series, error) {
+	if len(dates) != len(values) {
+		return Series{}, fmt.Errorf("quandl: %d dates but %d values", len(dates), len(values))
+	}
+
+	s := Series{
+		Dates:  append([]string(nil), dates...),
+		Values: append([]float64(nil), values...),
+	}
+	sort.Stable(seriesByDate(s))
+
+	return s, nil
+}
+
+// Series returns column as a Series, oldest first. Rows where column is
+// missing are left out.
+func (q *QuandlResponse) Series(column string) (Series, error) {
+	dates, values, err := q.parseTimeSeries(column, true)
+	if err != nil {
+		return Series{}, err
+	}
+
+	return NewSeries(dates, values)
+}
+
+// Len returns the number of observations.
+func (s Series) Len() int {
+	return len(s.Dates)
+}
+
+// Between returns the observations from start to end inclusive. An empty
+// start or end leaves that side open.
+func (s Series) Between(start string, end string) Series {
+	i := 0
+	if start != "" {
+		i = sort.SearchStrings(s.Dates, start)
+	}
+	j := len(s.Dates)
+	if end != "" {
+		j = sort.Search(len(s.Dates), func(k int) bool { return s.Dates[k] > end })
+	}
+	if i > j {
+		i = j
+	}
+
+	return Series{Dates: s.Dates[i:j], Values: s.Values[i:j]}
+}
+
+// Value returns the value on date, if there is one.
+func (s Series) Value(date string) (float64, bool) {
+	i := sort.SearchStrings(s.Dates, date)
+	if i == len(s.Dates) || s.Dates[i] != date {
+		return 0, false
+	}
+
+	return s.Values[i], true
+}
+
+// seriesByDate sorts a Series by date.
+type seriesByDate Series
+
+func (s seriesByDate) Len() int           { return len(s.Dates) }
+func (s seriesByDate) Less(i, j int) bool { return s.Dates[i] < s.Dates[j] }
+func (s seriesByDate) Swap(i, j int) {
+	s.Dates[i], s.Dates[j] = s.Dates[j], s.Dates[i]
+	s.Values[i], s.Values[j] = s.Values[j], s.Values[i]
+}
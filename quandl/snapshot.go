@@ -0,0 +1,291 @@
+package quandl
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Snapshot is a dated copy of a catalog or constituent list, e.g. the S&P
+// 500 members on a given day.
+type Snapshot struct {
+	Name       string // e.g. SP500, used as a directory name
+	Date       time.Time
+	Securities []Security
+}
+
+// ConstituentSnapshot makes a snapshot of index members named after the
+// index, e.g. SP500.
+func ConstituentSnapshot(index Index, date time.Time, members []Constituent) Snapshot {
+	snapshot := Snapshot{Name: index.snapshotName(), Date: date}
+	for _, m := range members {
+		snapshot.Securities = append(snapshot.Securities, m.Security())
+	}
+
+	return snapshot
+}
+
+// CatalogSnapshot makes a snapshot of every security in catalog.
+func CatalogSnapshot(name string, date time.Time, catalog *Catalog) Snapshot {
+	return Snapshot{Name: name, Date: date, Securities: catalog.Securities()}
+}
+
+func (i Index) snapshotName() string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, i.String())
+}
+
+// SnapshotStore keeps snapshots as CSV files in a directory, one
+// subdirectory per name and one file per date, e.g. dir/SP500/2016-01-04.csv.
+type SnapshotStore struct {
+	dir string
+}
+
+// NewSnapshotStore creates a store in dir, creating it if necessary.
+func NewSnapshotStore(dir string) (*SnapshotStore, error) {
+	if dir == "" {
+		return nil, errors.New("quandl: empty snapshot directory")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	return &SnapshotStore{dir: dir}, nil
+}
+
+// snapshotColumns are the columns of a snapshot file, in order.
+var snapshotColumns = []string{
+	FieldCode, FieldTicker, FieldName, FieldAssetClass, FieldExchange,
+	FieldRatiosCode, FieldActive, FieldSector, FieldIndustry,
+}
+
+var snapshotMapping = func() Mapping {
+	mapping := make(Mapping, len(snapshotColumns))
+	for i, field := range snapshotColumns {
+		mapping[i] = Column{Field: field, Headers: []string{field}, Required: field == FieldCode}
+	}
+	return mapping
+}()
+
+func (s *SnapshotStore) path(name string, date time.Time) (string, error) {
+	if name == "" || name != filepath.Base(name) || name == "." || name == ".." {
+		return "", fmt.Errorf("quandl: invalid snapshot name %q", name)
+	}
+
+	return filepath.Join(s.dir, name, date.Format(dateFormat)+".csv"), nil
+}
+
+// Save writes snapshot, replacing any snapshot with the same name and date.
+func (s *SnapshotStore) Save(snapshot Snapshot) error {
+	path, err := s.path(snapshot.Name, snapshot.Date)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(path), "tmp-")
+	if err != nil {
+		return err
+	}
+
+	w := csv.NewWriter(f)
+	w.Write(snapshotColumns)
+	for _, sec := range snapshot.Securities {
+		values := sec.fieldValues()
+		values[FieldAssetClass] = sec.AssetClass.String()
+		if sec.Active || sec.Delisted {
+			values[FieldActive] = strconv.FormatBool(sec.Active)
+		}
+
+		record := make([]string, len(snapshotColumns))
+		for i, field := range snapshotColumns {
+			record[i] = values[field]
+		}
+		w.Write(record)
+	}
+	w.Flush()
+
+	if err = w.Error(); err == nil {
+		err = f.Close()
+	} else {
+		f.Close()
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+
+	return err
+}
+
+// Load reads the snapshot with the given name and date.
+func (s *SnapshotStore) Load(name string, date time.Time) (Snapshot, error) {
+	path, err := s.path(name, date)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	defer f.Close()
+
+	list, err := ParseList(f, snapshotMapping)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("quandl: %s: %v", path, err)
+	}
+
+	snapshot := Snapshot{Name: name, Date: date}
+	for _, r := range list {
+		active, err := strconv.ParseBool(r[FieldActive])
+		known := err == nil
+		snapshot.Securities = append(snapshot.Securities, Security{
+			Code:       r[FieldCode],
+			Ticker:     r[FieldTicker],
+			Name:       r[FieldName],
+			AssetClass: parseAssetClass(r[FieldAssetClass]),
+			Exchange:   r[FieldExchange],
+			RatiosCode: r[FieldRatiosCode],
+			Sector:     r[FieldSector],
+			Industry:   r[FieldIndustry],
+			Active:     known && active,
+			Delisted:   known && !active,
+		})
+	}
+
+	return snapshot, nil
+}
+
+// Dates returns the dates of every snapshot with the given name, oldest
+// first.
+func (s *SnapshotStore) Dates(name string) ([]time.Time, error) {
+	path, err := s.path(name, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := ioutil.ReadDir(filepath.Dir(path))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var dates []time.Time
+	for _, entry := range entries {
+		date, err := time.Parse(dateFormat, strings.TrimSuffix(entry.Name(), ".csv"))
+		if err == nil && strings.HasSuffix(entry.Name(), ".csv") {
+			dates = append(dates, date)
+		}
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	return dates, nil
+}
+
+// AsOf returns the latest snapshot with the given name taken on or before
+// date, which is the point in time universe for that day.
+func (s *SnapshotStore) AsOf(name string, date time.Time) (Snapshot, error) {
+	dates, err := s.Dates(name)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	i := sort.Search(len(dates), func(i int) bool { return dates[i].After(date) })
+	if i == 0 {
+		return Snapshot{}, fmt.Errorf("quandl: no %s snapshot on or before %s", name, date.Format(dateFormat))
+	}
+
+	return s.Load(name, dates[i-1])
+}
+
+// Rename is a security whose name or ticker changed between two snapshots.
+type Rename struct {
+	Old Security
+	New Security
+}
+
+// SnapshotDiff is what changed between two snapshots.
+type SnapshotDiff struct {
+	Added   []Security
+	Removed []Security
+	Renamed []Rename
+}
+
+// DiffSnapshots compares snapshot from with the later snapshot to. A
+// security with the same code whose name or ticker changed is renamed, as is
+// a removed security whose name matches an added one, since a ticker change
+// usually means a new code.
+func DiffSnapshots(from Snapshot, to Snapshot) SnapshotDiff {
+	var diff SnapshotDiff
+
+	oldByCode := make(map[string]Security, len(from.Securities))
+	for _, s := range from.Securities {
+		oldByCode[s.Code] = s
+	}
+	newByCode := make(map[string]bool, len(to.Securities))
+
+	var added []Security
+	for _, s := range to.Securities {
+		newByCode[s.Code] = true
+
+		before, ok := oldByCode[s.Code]
+		switch {
+		case !ok:
+			added = append(added, s)
+		case before.Name != s.Name || before.Ticker != s.Ticker:
+			diff.Renamed = append(diff.Renamed, Rename{Old: before, New: s})
+		}
+	}
+
+	var removed []Security
+	for _, s := range from.Securities {
+		if !newByCode[s.Code] {
+			removed = append(removed, s)
+		}
+	}
+
+	removedByName := make(map[string]int)
+	for i, s := range removed {
+		if key := normalizeHeader(s.Name); key != "" {
+			if _, ok := removedByName[key]; !ok {
+				removedByName[key] = i
+			}
+		}
+	}
+
+	matched := make(map[int]bool)
+	for _, s := range added {
+		if i, ok := removedByName[normalizeHeader(s.Name)]; ok && !matched[i] {
+			matched[i] = true
+			diff.Renamed = append(diff.Renamed, Rename{Old: removed[i], New: s})
+			continue
+		}
+		diff.Added = append(diff.Added, s)
+	}
+
+	for i, s := range removed {
+		if !matched[i] {
+			diff.Removed = append(diff.Removed, s)
+		}
+	}
+
+	return diff
+}
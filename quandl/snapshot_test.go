@@ -0,0 +1,60 @@
+package quandl
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+func ExampleDiffSnapshots() {
+	dir, _ := os.MkdirTemp("", "snapshots")
+	defer os.RemoveAll(dir)
+
+	store, _ := NewSnapshotStore(dir)
+
+	jan := "Ticker,Name,Sector\nAAPL,Apple Inc.,Information Technology\nFB,Facebook Inc.,Information Technology\nLEH,Lehman Brothers,Financials\n"
+	feb := "Ticker,Name,Sector\nAAPL,Apple Inc,Information Technology\nMETA,Facebook Inc.,Communication Services\nTSLA,Tesla Inc.,Consumer Discretionary\n"
+
+	for date, list := range map[string]string{"2016-01-04": jan, "2016-02-01": feb} {
+		members, _ := ParseIndexConstituents(SP500, strings.NewReader(list))
+		day, _ := time.Parse("2006-01-02", date)
+		if err := store.Save(ConstituentSnapshot(SP500, day, members)); err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
+
+	mid, _ := time.Parse("2006-01-02", "2016-01-20")
+	from, err := store.AsOf("SP500", mid)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	dates, _ := store.Dates("SP500")
+	to, _ := store.Load("SP500", dates[len(dates)-1])
+
+	fmt.Println(from.Date.Format("2006-01-02"), "to", to.Date.Format("2006-01-02"))
+
+	diff := DiffSnapshots(from, to)
+	for _, s := range diff.Added {
+		fmt.Println("added", s.Code, s.Sector)
+	}
+	for _, s := range diff.Removed {
+		fmt.Println("removed", s.Code)
+	}
+	for _, r := range diff.Renamed {
+		fmt.Printf("renamed %s %q to %s %q\n", r.Old.Code, r.Old.Name, r.New.Code, r.New.Name)
+	}
+
+	_, err = store.AsOf("SP500", time.Date(2015, 12, 31, 0, 0, 0, 0, time.UTC))
+	fmt.Println(err)
+
+	// Output:
+	// 2016-01-04 to 2016-02-01
+	// added WIKI/TSLA Consumer Discretionary
+	// removed WIKI/LEH
+	// renamed WIKI/AAPL "Apple Inc." to WIKI/AAPL "Apple Inc"
+	// renamed WIKI/FB "Facebook Inc." to WIKI/META "Facebook Inc."
+	// quandl: no SP500 snapshot on or before 2015-12-31
+}
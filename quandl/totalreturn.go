@@ -0,0 +1,87 @@
+package quandl
+
+import "fmt"
+
+// TotalReturnOptions configures a total return index.
+type TotalReturnOptions struct {
+	// WithholdingTax is the fraction of each dividend lost to tax before it
+	// is reinvested, e.g. 0.15. Zero reinvests dividends in full.
+	WithholdingTax float64
+
+	// Base is the level the index starts at, 100 if zero.
+	Base float64
+
+	// PriceColumn is the raw price column to use, Close if empty. It must be
+	// unadjusted, since the dividends and splits are applied here.
+	PriceColumn string
+}
+
+// TotalReturn builds a total return index from the raw prices, dividends and
+// splits of a dataset with Ex-Dividend and Split Ratio columns, such as a
+// WIKI dataset. See TotalReturnIndex.
+func (q *QuandlResponse) TotalReturn(opts TotalReturnOptions) (Series, error) {
+	column := opts.PriceColumn
+	if column == "" {
+		column = ColumnClose
+	}
+
+	prices, err := q.Series(column)
+	if err != nil {
+		return Series{}, err
+	}
+
+	actions, err := q.CorporateActions()
+	if err != nil {
+		return Series{}, err
+	}
+
+	return TotalReturnIndex(prices, actions, opts)
+}
+
+// TotalReturnIndex builds a total return index from raw prices, oldest
+// first, and the corporate actions over the same dates. Each dividend, less
+// withholding tax, is reinvested at the price on its ex-date, and each split
+// multiplies the shares held, so on a day with price P, dividend D and split
+// ratio R the index moves by R * (P + D*(1-tax)) / previous P. Actions on
+// dates without a price are ignored.
+func TotalReturnIndex(prices Series, actions CorporateActions, opts TotalReturnOptions) (Series, error) {
+	if opts.WithholdingTax < 0 || opts.WithholdingTax > 1 {
+		return Series{}, fmt.Errorf("quandl: withholding tax %v is not between 0 and 1", opts.WithholdingTax)
+	}
+
+	base := opts.Base
+	if base == 0 {
+		base = 100
+	}
+
+	dividends := make(map[string]float64, len(actions.Dividends))
+	for _, d := range actions.Dividends {
+		dividends[d.Date] += d.Amount
+	}
+	splits := make(map[string]float64, len(actions.Splits))
+	for _, s := range actions.Splits {
+		splits[s.Date] = s.Ratio
+	}
+
+	index := Series{Dates: prices.Dates, Values: make([]float64, prices.Len())}
+	level := base
+	for i, date := range prices.Dates {
+		if i > 0 {
+			previous := prices.Values[i-1]
+			if previous == 0 {
+				return Series{}, fmt.Errorf("quandl: zero price on %s", prices.Dates[i-1])
+			}
+
+			ratio := 1.0
+			if r, ok := splits[date]; ok && r != 0 {
+				ratio = r
+			}
+
+			level *= ratio * (prices.Values[i] + dividends[date]*(1-opts.WithholdingTax)) / previous
+		}
+
+		index.Values[i] = level
+	}
+
+	return index, nil
+}
@@ -0,0 +1,27 @@
+package quandl
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+func ExampleQuandlResponse_TotalReturn() {
+	var q QuandlResponse
+	json.Unmarshal([]byte(wikiDataset), &q)
+
+	gross, err := q.TotalReturn(TotalReturnOptions{})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	net, _ := q.TotalReturn(TotalReturnOptions{WithholdingTax: 0.3})
+
+	fmt.Println(gross.Dates)
+	fmt.Printf("%.3f\n", gross.Values)
+	fmt.Printf("%.3f\n", net.Values)
+
+	// Output:
+	// [2014-06-05 2014-06-06 2014-06-09 2014-06-10]
+	// [100.000 102.041 103.061 105.122]
+	// [100.000 102.041 102.755 104.810]
+}